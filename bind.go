@@ -0,0 +1,310 @@
+package api
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/codec/json"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// tagDefault default value of the field when it is not sent by the client, e.g. `default:"20"`.
+	tagDefault = "default"
+
+	defaultMultipartMemory = 32 << 20 // 32 MB, the same as gin
+)
+
+var (
+	_ BindSource = BindSourceFunc(nil)
+
+	// BindURI binds the path params by the "uri" tag.
+	BindURI BindSource = BindSourceFunc(bindURI)
+	// BindQuery binds the query string by the "form" tag.
+	BindQuery BindSource = BindSourceFunc(bindQuery)
+	// BindHeader binds the request headers by the "header" tag.
+	BindHeader BindSource = BindSourceFunc(bindHeader)
+	// BindBody binds the request body by the Content-Type, an empty body is skipped.
+	BindBody BindSource = BindSourceFunc(bindBody)
+
+	defaultBindSources = []BindSource{BindURI, BindQuery, BindBody}
+
+	errBindUnsupportedType = errors.New("unsupported bind type")
+)
+
+// BindSource binds the request data into obj without validation.
+type BindSource interface {
+	Bind(ctx *gin.Context, obj any) error
+}
+
+type BindSourceFunc func(ctx *gin.Context, obj any) error
+
+func (f BindSourceFunc) Bind(ctx *gin.Context, obj any) error {
+	return f(ctx, obj)
+}
+
+// BindWith binds with a gin binding, validation errors are deferred to Bind().
+func BindWith(b binding.Binding) BindSource {
+	return BindSourceFunc(func(ctx *gin.Context, obj any) error {
+		return skipValidationError(ctx.ShouldBindWith(obj, b))
+	})
+}
+
+// Bind sets the default values of a new T (a struct or a pointer to struct), then binds the request data
+// from the sources in order, default BindURI, BindQuery and BindBody, and validates once.
+// The values sent by the client are kept even if they are zero, e.g. "count":0.
+// The VError response has been written when ok is false, a malformed default tag is responded as 500.
+func Bind[T any](ctx *gin.Context, sources ...BindSource) (T, bool) {
+	var (
+		req T
+		obj any = &req
+	)
+
+	rt := reflect.TypeFor[T]()
+	if rt.Kind() == reflect.Pointer {
+		rv := reflect.New(rt.Elem())
+		req, _ = rv.Interface().(T)
+		obj = req
+	}
+
+	if len(sources) == 0 {
+		sources = defaultBindSources
+	}
+
+	var zero T
+
+	// Programmer error, not a bad request
+	if err := setDefaults(reflect.ValueOf(obj)); err != nil {
+		Error(ctx, fmt.Errorf("api.Bind: %w", err))
+		return zero, false
+	}
+
+	if err := bind(ctx, obj, sources); err != nil {
+		VError(ctx, err, obj)
+		return zero, false
+	}
+
+	return req, true
+}
+
+func bind(ctx *gin.Context, obj any, sources []BindSource) error {
+	for _, s := range sources {
+		if err := s.Bind(ctx, obj); err != nil {
+			return err
+		}
+	}
+
+	if binding.Validator == nil {
+		return nil
+	}
+
+	return binding.Validator.ValidateStruct(obj) //nolint:wrapcheck
+}
+
+func bindURI(ctx *gin.Context, obj any) error {
+	m := make(map[string][]string, len(ctx.Params))
+	for _, p := range ctx.Params {
+		m[p.Key] = []string{p.Value}
+	}
+
+	return binding.MapFormWithTag(obj, m, "uri") //nolint:wrapcheck
+}
+
+func bindQuery(ctx *gin.Context, obj any) error {
+	return binding.MapFormWithTag(obj, ctx.Request.URL.Query(), "form") //nolint:wrapcheck
+}
+
+func bindHeader(ctx *gin.Context, obj any) error {
+	// Header names in tags are case-insensitive
+	m := map[string][]string{}
+	for _, name := range tagNames(reflect.TypeOf(obj), "header") {
+		if vs := ctx.Request.Header.Values(textproto.CanonicalMIMEHeaderKey(name)); len(vs) > 0 {
+			m[name] = vs
+		}
+	}
+
+	return binding.MapFormWithTag(obj, m, "header") //nolint:wrapcheck
+}
+
+func bindBody(ctx *gin.Context, obj any) error {
+	req := ctx.Request
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	switch ctx.ContentType() {
+	case binding.MIMEPOSTForm:
+		if err := req.ParseForm(); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return binding.MapFormWithTag(obj, req.PostForm, "form") //nolint:wrapcheck
+	case binding.MIMEMultipartPOSTForm:
+		if err := req.ParseMultipartForm(defaultMultipartMemory); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return binding.MapFormWithTag(obj, req.MultipartForm.Value, "form") //nolint:wrapcheck
+	case binding.MIMEXML, binding.MIMEXML2:
+		return skipEOF(xml.NewDecoder(req.Body).Decode(obj))
+	case binding.MIMEJSON, "":
+		dec := json.API.NewDecoder(req.Body)
+		if binding.EnableDecoderUseNumber {
+			dec.UseNumber()
+		}
+		if binding.EnableDecoderDisallowUnknownFields {
+			dec.DisallowUnknownFields()
+		}
+
+		return skipEOF(dec.Decode(obj))
+	default:
+		return skipValidationError(ctx.ShouldBindWith(obj, binding.Default(req.Method, ctx.ContentType())))
+	}
+}
+
+// skipEOF an empty body is not an error, required fields are reported by validation.
+func skipEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return err
+}
+
+func skipValidationError(err error) error {
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		return nil
+	}
+
+	return err
+}
+
+// tagNames names of the tag in the struct type, including nested structs.
+func tagNames(t reflect.Type, tag string) []string {
+	t = indirectType(t)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+			continue
+		}
+
+		names = append(names, tagNames(f.Type, tag)...)
+	}
+
+	return names
+}
+
+// setDefaults sets the fields by the default tag before binding, including nested structs,
+// nested struct pointers allocated by the binding are not set.
+func setDefaults(v reflect.Value) error {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct || v.Type() == reflect.TypeFor[time.Time]() {
+		return nil
+	}
+
+	t := v.Type()
+
+	for i := range t.NumField() {
+		f, fv := t.Field(i), v.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		def, ok := f.Tag.Lookup(tagDefault)
+		if !ok {
+			if err := setDefaults(fv); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := setValue(fv, def); err != nil {
+			return fmt.Errorf("default value of %s: %w", f.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func setValue(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+
+	if v.Type() == reflect.TypeFor[time.Duration]() {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		v.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("%w: %s", errBindUnsupportedType, v.Type())
+	}
+
+	return nil
+}
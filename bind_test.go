@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type testBindReq struct {
+	ID        int           `binding:"required" uri:"id"`
+	Token     string        `binding:"required" header:"X-Api-Token"`
+	Lang      string        `default:"en"       form:"lang"`
+	Name      string        `binding:"required" json:"name"`
+	Count     int           `default:"10"       json:"count"`
+	Timeout   time.Duration `default:"3s"       json:"timeout"`
+	Published *bool         `default:"true"     json:"published"`
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	r := gin.New()
+	r.POST("/bind/:id", func(ctx *gin.Context) {
+		req, ok := Bind[*testBindReq](ctx, BindURI, BindHeader, BindQuery, BindBody)
+		if !ok {
+			return
+		}
+
+		Success(ctx, req)
+	})
+	r.GET("/bind-default/:id", func(ctx *gin.Context) {
+		req, ok := Bind[testBindReq](ctx)
+		if !ok {
+			return
+		}
+
+		Success(ctx, req.Lang)
+	})
+	r.POST("/bind-malformed", func(ctx *gin.Context) {
+		req, ok := Bind[struct {
+			Count int `default:"ten" json:"count"`
+		}](ctx)
+		if !ok {
+			return
+		}
+
+		Success(ctx, req)
+	})
+
+	tests := []struct {
+		name     string
+		uri      string
+		token    string
+		body     string
+		httpCode int
+		data     string
+		errField string
+	}{
+		{
+			name:     "ok",
+			uri:      "/bind/1?lang=de",
+			token:    "t",
+			body:     `{"name":"foo"}`,
+			httpCode: http.StatusOK,
+			data:     `{"ID":1,"Token":"t","Lang":"de","name":"foo","count":10,"timeout":3000000000,"published":true}`,
+		},
+		{
+			name:     "explicit-zero",
+			uri:      "/bind/1?lang=",
+			token:    "t",
+			body:     `{"name":"foo","count":0,"timeout":0,"published":false}`,
+			httpCode: http.StatusOK,
+			data:     `{"ID":1,"Token":"t","Lang":"","name":"foo","count":0,"timeout":0,"published":false}`,
+		},
+		{
+			name:     "malformed-default",
+			uri:      "/bind-malformed",
+			httpCode: http.StatusInternalServerError,
+		},
+		{
+			name:     "keep-values",
+			uri:      "/bind/2",
+			token:    "t",
+			body:     `{"name":"foo","count":5,"published":false}`,
+			httpCode: http.StatusOK,
+			data:     `{"ID":2,"Token":"t","Lang":"en","name":"foo","count":5,"timeout":3000000000,"published":false}`,
+		},
+		{
+			name:     "missing-header",
+			uri:      "/bind/1",
+			body:     `{"name":"foo"}`,
+			httpCode: http.StatusBadRequest,
+			errField: "X-Api-Token",
+		},
+		{
+			name:     "empty-body",
+			uri:      "/bind/1",
+			token:    "t",
+			httpCode: http.StatusBadRequest,
+			errField: "name",
+		},
+		{
+			name:     "invalid-body",
+			uri:      "/bind/1",
+			token:    "t",
+			body:     `{"name":1}`,
+			httpCode: http.StatusBadRequest,
+			errField: "name",
+		},
+		{
+			name:     "invalid-uri",
+			uri:      "/bind/abc",
+			token:    "t",
+			body:     `{"name":"foo"}`,
+			httpCode: http.StatusBadRequest,
+		},
+		{
+			name:     "default-sources",
+			uri:      "/bind-default/1",
+			httpCode: http.StatusBadRequest,
+			errField: "X-Api-Token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			method := http.MethodPost
+			if strings.HasPrefix(tt.uri, "/bind-default") {
+				method = http.MethodGet
+			}
+
+			req, _ := http.NewRequestWithContext(context.Background(), method, tt.uri, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.token != "" {
+				req.Header.Set("X-Api-Token", tt.token)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var resp struct {
+				Data   json.RawMessage `json:"data"`
+				Errors []DetailError   `json:"errors"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.httpCode, w.Code)
+
+			if tt.data != "" {
+				assert.JSONEq(t, tt.data, string(resp.Data))
+			}
+
+			if tt.errField != "" && assert.NotEmpty(t, resp.Errors) {
+				assert.Equal(t, tt.errField, resp.Errors[0].Field)
+			}
+		})
+	}
+}
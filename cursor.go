@@ -0,0 +1,226 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/litsea/gin-api/errcode"
+)
+
+const cursorCodecCtxKey = "litsea.gin-api.cursor-codec"
+
+var (
+	_ CursorCodec = (*HMACCursorCodec)(nil)
+
+	ErrCursorInvalid = errors.New("invalid cursor")
+	ErrCursorExpired = errors.New("cursor expired")
+)
+
+// CursorCodec encodes cursor values into opaque tokens and decodes them back.
+type CursorCodec interface {
+	Encode(v any) (string, error)
+	Decode(token string, v any) error
+}
+
+// HMACCursorCodec base64url JSON cursor token authenticated by HMAC-SHA256.
+type HMACCursorCodec struct {
+	keys [][]byte // The first key signs, all keys verify
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+type CursorOption func(*HMACCursorCodec)
+
+// WithCursorVerifyKeys previous keys still accepted for decoding during key rotation.
+func WithCursorVerifyKeys(keys ...[]byte) CursorOption {
+	return func(c *HMACCursorCodec) {
+		c.keys = append(c.keys, keys...)
+	}
+}
+
+// WithCursorTTL tokens expire after ttl, default never expire.
+func WithCursorTTL(ttl time.Duration) CursorOption {
+	return func(c *HMACCursorCodec) {
+		c.ttl = ttl
+	}
+}
+
+type cursorPayload struct {
+	Cursor  json.RawMessage `json:"c"`
+	Expires int64           `json:"e,omitempty"`
+}
+
+func NewHMACCursorCodec(key []byte, opts ...CursorOption) *HMACCursorCodec {
+	c := &HMACCursorCodec{
+		keys: [][]byte{key},
+		now:  time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Encode token format: base64url(payload).base64url(signature).
+func (c *HMACCursorCodec) Encode(v any) (string, error) {
+	cur, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("api.HMACCursorCodec.Encode: %w", err)
+	}
+
+	cp := cursorPayload{Cursor: cur}
+	if c.ttl > 0 {
+		cp.Expires = c.now().Add(c.ttl).Unix()
+	}
+
+	payload, err := json.Marshal(cp)
+	if err != nil {
+		return "", fmt.Errorf("api.HMACCursorCodec.Encode: %w", err)
+	}
+
+	enc := base64.RawURLEncoding
+
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(sign(c.keys[0], payload)), nil
+}
+
+func (c *HMACCursorCodec) Decode(token string, v any) error {
+	enc := base64.RawURLEncoding
+
+	p, s, ok := strings.Cut(token, ".")
+	if !ok {
+		return fmt.Errorf("api.HMACCursorCodec.Decode: %w", ErrCursorInvalid)
+	}
+
+	payload, err := enc.DecodeString(p)
+	if err != nil {
+		return fmt.Errorf("api.HMACCursorCodec.Decode: %w, %w", ErrCursorInvalid, err)
+	}
+
+	sig, err := enc.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("api.HMACCursorCodec.Decode: %w, %w", ErrCursorInvalid, err)
+	}
+
+	if !c.verify(payload, sig) {
+		return fmt.Errorf("api.HMACCursorCodec.Decode: %w, signature mismatch", ErrCursorInvalid)
+	}
+
+	var cp cursorPayload
+	if err := json.Unmarshal(payload, &cp); err != nil {
+		return fmt.Errorf("api.HMACCursorCodec.Decode: %w, %w", ErrCursorInvalid, err)
+	}
+
+	if cp.Expires > 0 && c.now().Unix() > cp.Expires {
+		return fmt.Errorf("api.HMACCursorCodec.Decode: %w", ErrCursorExpired)
+	}
+
+	if err := json.Unmarshal(cp.Cursor, v); err != nil {
+		return fmt.Errorf("api.HMACCursorCodec.Decode: %w, %w", ErrCursorInvalid, err)
+	}
+
+	return nil
+}
+
+func (c *HMACCursorCodec) verify(payload, sig []byte) bool {
+	for _, k := range c.keys {
+		if hmac.Equal(sig, sign(k, payload)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sign(key, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+// DecodeCursor decode the cursor token into v, the error can be responded by Error:
+// errcode.ErrBadRequestCursorExpired on expiry, otherwise errcode.ErrBadRequestCursorInvalid.
+func DecodeCursor(codec CursorCodec, token string, v any) error {
+	err := codec.Decode(token, v)
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, ErrCursorExpired) {
+		return fmt.Errorf("%w, %w", errcode.ErrBadRequestCursorExpired, err)
+	}
+
+	return fmt.Errorf("%w, %w", errcode.ErrBadRequestCursorInvalid, err)
+}
+
+// CursorCodecMiddleware CursorPageSuccess encodes the start and next cursors with codec
+// for an engine or a route group, BindCursor also uses it when no codec is given.
+func CursorCodecMiddleware(codec CursorCodec) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if codec != nil {
+			SetCursorCodecToContext(ctx, codec)
+		}
+
+		ctx.Next()
+	}
+}
+
+// GetCursorCodecFromContext get cursor codec set by SetCursorCodecToContext()
+//
+//nolint:ireturn
+func GetCursorCodecFromContext(ctx *gin.Context) CursorCodec {
+	v, exists := ctx.Get(cursorCodecCtxKey)
+	if !exists {
+		return nil
+	}
+
+	c, ok := v.(CursorCodec)
+	if !ok {
+		return nil
+	}
+
+	return c
+}
+
+func SetCursorCodecToContext(ctx *gin.Context, codec CursorCodec) {
+	ctx.Set(cursorCodecCtxKey, codec)
+}
+
+// responseCursor the cursor as is without the codec, otherwise the encoded cursor,
+// nil for the zero cursor (no next page).
+func responseCursor(codec CursorCodec, v any) (any, error) {
+	if codec == nil {
+		return v, nil
+	}
+
+	if isZeroCursor(v) {
+		return nil, nil
+	}
+
+	return codec.Encode(v) //nolint:wrapcheck
+}
+
+// encodeCursor zero cursor (no next page) is encoded as an empty token.
+func encodeCursor(codec CursorCodec, v any) (string, error) {
+	if isZeroCursor(v) {
+		return "", nil
+	}
+
+	return codec.Encode(v) //nolint:wrapcheck
+}
+
+// isZeroCursor nil or zero cursor, e.g. "" or 0, means no next page.
+func isZeroCursor(v any) bool {
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
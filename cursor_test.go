@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/litsea/gin-api/errcode"
+)
+
+func TestHMACCursorCodec(t *testing.T) {
+	t.Parallel()
+
+	oldKey := []byte("old-key")
+	newKey := []byte("new-key")
+
+	oldCodec := NewHMACCursorCodec(oldKey)
+	rotated := NewHMACCursorCodec(newKey, WithCursorVerifyKeys(oldKey))
+	expiring := NewHMACCursorCodec(newKey, WithCursorTTL(time.Minute))
+
+	oldToken, err := oldCodec.Encode(testCursor{ID: 1})
+	assert.NoError(t, err)
+
+	newToken, err := rotated.Encode(testCursor{ID: 2})
+	assert.NoError(t, err)
+
+	expToken, err := expiring.Encode(testCursor{ID: 3})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		codec  *HMACCursorCodec
+		token  string
+		wantID int
+		want   error
+	}{
+		{name: "rotated-old-key", codec: rotated, token: oldToken, wantID: 1},
+		{name: "rotated-new-key", codec: rotated, token: newToken, wantID: 2},
+		{name: "old-codec-new-key", codec: oldCodec, token: newToken, want: errcode.ErrBadRequestCursorInvalid},
+		{name: "tampered", codec: rotated, token: "e30" + newToken, want: errcode.ErrBadRequestCursorInvalid},
+		{name: "malformed", codec: rotated, token: "abc", want: errcode.ErrBadRequestCursorInvalid},
+		{name: "not-expired", codec: expiring, token: expToken, wantID: 3},
+		{
+			name: "expired",
+			codec: &HMACCursorCodec{
+				keys: expiring.keys,
+				now:  func() time.Time { return time.Now().Add(2 * time.Minute) },
+			},
+			token: expToken,
+			want:  errcode.ErrBadRequestCursorExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var cur testCursor
+			err := DecodeCursor(tt.codec, tt.token, &cur)
+
+			if tt.want != nil {
+				assert.ErrorIs(t, err, tt.want)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, cur.ID)
+		})
+	}
+}
+
+func TestCursorPageSuccessEncode(t *testing.T) {
+	t.Parallel()
+
+	r := gin.New()
+	r.Use(CursorCodecMiddleware(testCursorCodec))
+
+	r.GET("/cursor", func(ctx *gin.Context) {
+		var next *testCursor
+		if ctx.Query("last") == "" {
+			next = &testCursor{ID: 20}
+		}
+
+		CursorPageSuccess(ctx, 30, 10, testCursor{ID: 10}, next, []int{1})
+	})
+
+	tests := []struct {
+		name     string
+		uri      string
+		wantNext int
+	}{
+		{name: "next", uri: "/cursor", wantNext: 20},
+		{name: "last-page", uri: "/cursor?last=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var resp TypedResponse[TypedCursorPageResp[int, *string]]
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			assert.NoError(t, err)
+
+			var start testCursor
+			if assert.NotNil(t, resp.Data.Start) {
+				assert.NoError(t, testCursorCodec.Decode(*resp.Data.Start, &start))
+				assert.Equal(t, 10, start.ID)
+			}
+
+			if tt.wantNext == 0 {
+				assert.Nil(t, resp.Data.Next)
+				return
+			}
+
+			var next testCursor
+			if assert.NotNil(t, resp.Data.Next) {
+				assert.NoError(t, testCursorCodec.Decode(*resp.Data.Next, &next))
+				assert.Equal(t, tt.wantNext, next.ID)
+			}
+		})
+	}
+}
+
+func TestCursorPageSuccessWithoutCodec(t *testing.T) {
+	t.Parallel()
+
+	r := gin.New()
+	r.GET("/any", func(ctx *gin.Context) {
+		CursorPageSuccess(ctx, 10, 2, 0, 2, []int{1, 2})
+	})
+	r.GET("/typed", func(ctx *gin.Context) {
+		TypedCursorPageSuccess(ctx, 10, 2, 0, 2, []int{1, 2})
+	})
+
+	for _, uri := range []string{"/any", "/typed"} {
+		t.Run(uri, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, uri, http.NoBody)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			// Zero cursors are responded as is without the codec
+			var resp struct {
+				Data map[string]any `json:"data"`
+			}
+			if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp)) {
+				assert.Equal(t, float64(0), resp.Data["start"])
+				assert.Equal(t, float64(2), resp.Data["next"])
+			}
+		})
+	}
+}
+
+func TestCursorPageSuccessLastPage(t *testing.T) {
+	t.Parallel()
+
+	r := gin.New()
+	r.GET("/raw", func(ctx *gin.Context) {
+		CursorPageSuccess(ctx, 30, 10, "a", "", []int{1})
+	})
+
+	g := r.Group("/codec", CursorCodecMiddleware(testCursorCodec))
+	g.GET("/any", func(ctx *gin.Context) {
+		CursorPageSuccess(ctx, 30, 10, testCursor{ID: 10}, 0, []int{1})
+	})
+	g.GET("/typed", func(ctx *gin.Context) {
+		next := testCursor{ID: 20}
+		if ctx.Query("last") != "" {
+			next = testCursor{}
+		}
+
+		TypedCursorPageSuccess(ctx, 30, 10, testCursor{ID: 10}, next, []int{1})
+	})
+
+	tests := []struct {
+		name     string
+		uri      string
+		wantNext int
+	}{
+		{name: "raw-zero", uri: "/raw"},
+		{name: "codec-zero", uri: "/codec/any"},
+		{name: "typed-next", uri: "/codec/typed", wantNext: 20},
+		{name: "typed-last", uri: "/codec/typed?last=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var resp TypedResponse[TypedCursorPageResp[int, *string]]
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+			if tt.wantNext == 0 {
+				// null of CursorPageSuccess, "" of TypedCursorPageSuccess
+				assert.True(t, resp.Data.Next == nil || *resp.Data.Next == "")
+				return
+			}
+
+			// Typed cursors are encoded by the codec and decoded by BindCursor
+			var next testCursor
+			if assert.NotNil(t, resp.Data.Next) {
+				assert.NoError(t, DecodeCursor(testCursorCodec, *resp.Data.Next, &next))
+				assert.Equal(t, tt.wantNext, next.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/litsea/gin-api/errcode"
+)
+
+var errDecodeNilResponse = errors.New("nil http response")
+
+// Decode decodes the data of a gin-api HTTP response,
+// err is *errcode.Error carrying the code and the HTTP status when the response is an error.
+func Decode[T any](resp *http.Response) (T, error) {
+	r, err := DecodeResponse[T](resp)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return r.Data, nil
+}
+
+// DecodeResponse decodes the whole envelope of a gin-api HTTP response,
+// the envelope is also returned with the *errcode.Error to access the detail errors.
+// Problem details (RFC 9457) responses are supported.
+// The response body is consumed but not closed.
+func DecodeResponse[T any](resp *http.Response) (*TypedResponse[T], error) {
+	if resp == nil {
+		return nil, fmt.Errorf("api.DecodeResponse: %w", errDecodeNilResponse)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("api.DecodeResponse: read body: %w", err)
+	}
+
+	r := &TypedResponse[T]{}
+
+	ct, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if ct == ContentTypeProblemJSON {
+		err = decodeProblem(body, r)
+	} else {
+		err = json.Unmarshal(body, r)
+	}
+
+	if err != nil {
+		if resp.StatusCode >= http.StatusBadRequest {
+			// Non gin-api error response, e.g. from a proxy
+			return nil, errcode.NewUnregistered(resp.StatusCode, http.StatusText(resp.StatusCode), resp.StatusCode)
+		}
+
+		return nil, fmt.Errorf("api.DecodeResponse: %w", err)
+	}
+
+	if r.Code != errcode.CodeOK || resp.StatusCode >= http.StatusBadRequest {
+		return r, errcode.NewUnregistered(r.Code, r.Message, resp.StatusCode)
+	}
+
+	return r, nil
+}
+
+func decodeProblem[T any](body []byte, r *TypedResponse[T]) error {
+	var p Problem
+	if err := json.Unmarshal(body, (*problem)(&p)); err != nil {
+		return fmt.Errorf("decode problem: %w", err)
+	}
+
+	r.Code = p.Code
+	r.Message = p.Detail
+	r.Errors = p.Errors
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/litsea/gin-api/errcode"
+)
+
+type testUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func newTypedServer(mw ...gin.HandlerFunc) *gin.Engine {
+	r := newServer(mw...)
+
+	r.GET("/typed/user", func(ctx *gin.Context) {
+		TypedSuccess(ctx, testUser{ID: 1, Name: "foo"})
+	})
+
+	r.GET("/typed/users", func(ctx *gin.Context) {
+		TypedPageSuccess(ctx, 3, 2, 1, []testUser{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}})
+	})
+
+	r.GET("/typed/users/cursor", func(ctx *gin.Context) {
+		TypedCursorPageSuccess(ctx, 3, 2, 0, 2, []testUser{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}})
+	})
+
+	return r
+}
+
+func serveTyped(uri string, mw ...gin.HandlerFunc) *http.Response {
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, uri, http.NoBody)
+
+	w := httptest.NewRecorder()
+	newTypedServer(mw...).ServeHTTP(w, req)
+
+	return w.Result()
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		resp := serveTyped("/typed/user")
+		defer resp.Body.Close()
+
+		u, err := Decode[testUser](resp)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testUser{ID: 1, Name: "foo"}, u)
+	})
+
+	t.Run("page", func(t *testing.T) {
+		t.Parallel()
+
+		resp := serveTyped("/typed/users")
+		defer resp.Body.Close()
+
+		p, err := Decode[*TypedPageResponse[testUser]](resp)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), p.Total)
+		assert.Equal(t, 1, p.Page)
+		assert.Equal(t, []testUser{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}}, p.Items)
+	})
+
+	t.Run("cursor-page", func(t *testing.T) {
+		t.Parallel()
+
+		resp := serveTyped("/typed/users/cursor")
+		defer resp.Body.Close()
+
+		p, err := Decode[TypedCursorPageResp[testUser, int]](resp)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, p.Next)
+		assert.Len(t, p.Items, 2)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		resp := serveTyped("/403")
+		defer resp.Body.Close()
+
+		_, err := Decode[testUser](resp)
+
+		var ee *errcode.Error
+		assert.ErrorAs(t, err, &ee)
+		assert.Equal(t, errcode.ErrForbidden.Code, ee.Code)
+		assert.Equal(t, http.StatusForbidden, ee.HTTPCode())
+		assert.ErrorIs(t, err, errcode.ErrForbidden)
+	})
+
+	t.Run("validation-error", func(t *testing.T) {
+		t.Parallel()
+
+		resp := serveTyped("/validation/required")
+		defer resp.Body.Close()
+
+		r, err := DecodeResponse[any](resp)
+
+		assert.ErrorIs(t, err, errcode.ErrBadRequest)
+		if assert.Len(t, r.Errors, 1) {
+			assert.Equal(t, "name", r.Errors[0].Field)
+		}
+	})
+
+	t.Run("problem-error", func(t *testing.T) {
+		t.Parallel()
+
+		resp := serveTyped("/custom-503", ErrorFormat(NewProblemFormatter()))
+		defer resp.Body.Close()
+
+		_, err := Decode[testUser](resp)
+
+		assert.ErrorIs(t, err, errCustom503)
+		assert.False(t, errors.Is(err, errcode.ErrServiceUnavailable))
+	})
+}
@@ -1,6 +1,7 @@
 package errcode
 
 import (
+	"maps"
 	"net/http"
 )
 
@@ -15,9 +16,21 @@ type Error struct {
 	httpCode       int
 	Message        string `json:"msg"`
 	errLogDisabled bool
+	params         map[string]any
+	field          string
+	cause          error
 }
 
+// New create and register an error, see SetDuplicatePolicy() for duplicate codes.
 func New(code int, msg string, httpCode ...int) *Error {
+	e := NewUnregistered(code, msg, httpCode...)
+	reg.register(e)
+
+	return e
+}
+
+// NewUnregistered create an error without registration, e.g. errors decoded from API responses.
+func NewUnregistered(code int, msg string, httpCode ...int) *Error {
 	hc := http.StatusInternalServerError
 	if len(httpCode) != 0 {
 		hc = httpCode[0]
@@ -32,9 +45,73 @@ func (e *Error) HTTPCode() int {
 }
 
 func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+
 	return e.Message
 }
 
+// Unwrap returns the cause set by Wrap().
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// With returns a copy with the template params of the translated message, e.g. "{{.limit}}",
+// params are merged into the existing ones.
+func (e *Error) With(params map[string]any) *Error {
+	c := e.clone()
+	c.params = make(map[string]any, len(e.params)+len(params))
+	maps.Copy(c.params, e.params)
+	maps.Copy(c.params, params)
+
+	return c
+}
+
+// WithField returns a copy with the request field name which causes the error.
+func (e *Error) WithField(field string) *Error {
+	c := e.clone()
+	c.field = field
+
+	return c
+}
+
+// Wrap returns a copy with the cause, the cause is logged but not sent to the frontend.
+func (e *Error) Wrap(cause error) *Error {
+	c := e.clone()
+	c.cause = cause
+
+	return c
+}
+
+func (e *Error) Params() map[string]any {
+	return e.params
+}
+
+func (e *Error) Field() string {
+	return e.field
+}
+
+func (e *Error) Cause() error {
+	return e.cause
+}
+
+func (e *Error) clone() *Error {
+	c := *e
+	return &c
+}
+
+// Is errors with the same code are identical,
+// e.g. copies created by With()/WithField()/Wrap() and errors decoded from API responses.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || e == nil || t == nil {
+		return false
+	}
+
+	return e.Code == t.Code
+}
+
 func (e *Error) DisableErrorLog(v bool) *Error {
 	e.errLogDisabled = v
 	return e
@@ -62,6 +139,8 @@ var (
 	ErrBadRequestFormatNumeric = New(1002, "ErrBadRequestFormatNumeric", http.StatusBadRequest)
 	ErrBadRequestFormatTime    = New(1003, "ErrBadRequestFormatTime", http.StatusBadRequest)
 	ErrBadRequestFormatJSON    = New(1004, "ErrBadRequestFormatJSON", http.StatusBadRequest)
+	ErrBadRequestCursorInvalid = New(1005, "ErrBadRequestCursorInvalid", http.StatusBadRequest)
+	ErrBadRequestCursorExpired = New(1006, "ErrBadRequestCursorExpired", http.StatusBadRequest)
 
 	// server common error.
 
@@ -0,0 +1,44 @@
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorWith(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("db: duplicate key")
+	base := NewUnregistered(900100, "ErrEmailLimit", http.StatusBadRequest)
+
+	e := base.With(map[string]any{"limit": 3}).
+		With(map[string]any{"domain": "example.com"}).
+		WithField("email").
+		Wrap(cause)
+	err := fmt.Errorf("service: %w", e)
+
+	assert.ErrorIs(t, err, base)
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "service: ErrEmailLimit: db: duplicate key", err.Error())
+
+	var ee *Error
+	assert.ErrorAs(t, err, &ee)
+	assert.Equal(t, 900100, ee.Code)
+	assert.Equal(t, http.StatusBadRequest, ee.HTTPCode())
+	assert.Equal(t, map[string]any{"limit": 3, "domain": "example.com"}, ee.Params())
+	assert.Equal(t, "email", ee.Field())
+	assert.Equal(t, cause, ee.Cause())
+
+	// The base error is untouched
+	assert.Nil(t, base.Params())
+	assert.Empty(t, base.Field())
+	assert.NoError(t, base.Cause())
+	assert.Equal(t, "ErrEmailLimit", base.Error())
+
+	_, ok := Lookup(900100)
+	assert.False(t, ok)
+}
@@ -0,0 +1,154 @@
+package errcode
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ error = (*Multi)(nil)
+
+	// joinErrorType the type of the errors.Join() result
+	joinErrorType = reflect.TypeOf(errors.Join(errors.New("")))
+)
+
+// Multi aggregated errors, e.g. one error per item of a batch request.
+type Multi struct {
+	errs []error
+}
+
+// Item flattened error of a multi error.
+type Item struct {
+	Path string // Index path of the batch item, e.g. "[2]" or "[2][0]"
+	Err  error
+}
+
+type indexError struct {
+	index int
+	err   error
+}
+
+func NewMulti(errs ...error) *Multi {
+	m := &Multi{}
+	m.Add(errs...)
+
+	return m
+}
+
+// Add nil errors are ignored.
+func (m *Multi) Add(errs ...error) *Multi {
+	for _, err := range errs {
+		if err != nil {
+			m.errs = append(m.errs, err)
+		}
+	}
+
+	return m
+}
+
+// AddAt add the error of the batch item at index, see At().
+func (m *Multi) AddAt(index int, err error) *Multi {
+	if err != nil {
+		m.errs = append(m.errs, At(index, err))
+	}
+
+	return m
+}
+
+func (m *Multi) Len() int {
+	return len(m.errs)
+}
+
+// Err returns nil when there is no error, otherwise m.
+func (m *Multi) Err() error {
+	if m == nil || len(m.errs) == 0 {
+		return nil
+	}
+
+	return m
+}
+
+func (m *Multi) Error() string {
+	ss := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		ss[i] = err.Error()
+	}
+
+	return strings.Join(ss, "; ")
+}
+
+func (m *Multi) Unwrap() []error {
+	return m.errs
+}
+
+// At marks err with the index of the batch item,
+// nested multi errors form an index path, e.g. "[2][0]".
+func At(index int, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &indexError{index: index, err: err}
+}
+
+func (e *indexError) Error() string {
+	return "[" + strconv.Itoa(e.index) + "]: " + e.err.Error()
+}
+
+func (e *indexError) Unwrap() error {
+	return e.err
+}
+
+// Flatten flattens *Multi and errors.Join() errors (also nested ones) with their index paths,
+// ok is false when err is not a multi error.
+func Flatten(err error) ([]Item, bool) {
+	if _, ok := multiBranches(err); !ok {
+		return nil, false
+	}
+
+	return flatten(nil, "", err), true
+}
+
+func flatten(items []Item, path string, err error) []Item {
+	if ie, ok := err.(*indexError); ok { //nolint:errorlint
+		return flatten(items, path+"["+strconv.Itoa(ie.index)+"]", ie.err)
+	}
+
+	if bs, ok := multiBranches(err); ok {
+		for _, b := range bs {
+			items = flatten(items, path, b)
+		}
+
+		return items
+	}
+
+	return append(items, Item{Path: path, Err: err})
+}
+
+// multiBranches finds the first multi error in the (single) wrapping chain of err.
+func multiBranches(err error) ([]error, bool) {
+	for err != nil {
+		switch e := err.(type) { //nolint:errorlint
+		case *Error:
+			// Do not look into the cause
+			return nil, false
+		case *Multi:
+			return e.errs, true
+		case interface{ Unwrap() []error }:
+			if reflect.TypeOf(err) != joinErrorType {
+				// e.g. fmt.Errorf() with multiple %w is a single error
+				return nil, false
+			}
+
+			return e.Unwrap(), true
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
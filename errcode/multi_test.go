@@ -0,0 +1,67 @@
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+
+	errFoo := errors.New("foo")
+
+	tests := []struct {
+		name string
+		err  error
+		want []Item
+		ok   bool
+	}{
+		{
+			name: "join",
+			err:  fmt.Errorf("batch: %w", errors.Join(ErrNotFound, At(2, ErrForbidden))),
+			want: []Item{{Err: ErrNotFound}, {Path: "[2]", Err: ErrForbidden}},
+			ok:   true,
+		},
+		{
+			name: "nested-multi",
+			err: NewMulti().
+				AddAt(1, NewMulti(nil).AddAt(0, ErrNotFound).AddAt(3, errFoo).Err()).
+				AddAt(2, nil).
+				Err(),
+			want: []Item{{Path: "[1][0]", Err: ErrNotFound}, {Path: "[1][3]", Err: errFoo}},
+			ok:   true,
+		},
+		{
+			name: "multiple-w",
+			err:  fmt.Errorf("service: %w, %w", ErrNotFound, errFoo),
+		},
+		{
+			name: "multiple-w-newline",
+			err:  fmt.Errorf("%w\n%w", ErrNotFound, errFoo),
+		},
+		{
+			name: "cause",
+			err:  ErrBadRequest.Wrap(errors.Join(ErrNotFound, ErrForbidden)),
+		},
+		{
+			name: "single",
+			err:  At(1, ErrNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := Flatten(tt.err)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.NoError(t, NewMulti().Err())
+	assert.EqualError(t, NewMulti(ErrNotFound).AddAt(1, errFoo), "ErrNotFound; [1]: foo")
+}
@@ -0,0 +1,199 @@
+package errcode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type DuplicatePolicy int
+
+const (
+	// DuplicateReport record duplicate codes, see Duplicates() and CheckDuplicates().
+	DuplicateReport DuplicatePolicy = iota
+	// DuplicatePanic panic when a duplicate code is created.
+	DuplicatePanic
+)
+
+var ErrDuplicateCode = errors.New("duplicate error code")
+
+type registry struct {
+	mu         sync.RWMutex
+	policy     DuplicatePolicy
+	errs       []*Error
+	byCode     map[int]*Error
+	duplicates []*Error
+}
+
+var reg = newRegistry()
+
+// Entry error catalog entry.
+type Entry struct {
+	Code             int    `json:"code"`
+	MsgID            string `json:"msgID"`
+	HTTPCode         int    `json:"httpCode"`
+	ErrorLogDisabled bool   `json:"errorLogDisabled"`
+}
+
+func newRegistry() *registry {
+	return &registry{
+		byCode: map[int]*Error{},
+	}
+}
+
+func (r *registry) register(e *Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if exist, ok := r.byCode[e.Code]; ok {
+		if r.policy == DuplicatePanic {
+			panic(duplicateError(exist, e))
+		}
+
+		r.duplicates = append(r.duplicates, e)
+		return
+	}
+
+	r.byCode[e.Code] = e
+	r.errs = append(r.errs, e)
+}
+
+// SetDuplicatePolicy default DuplicateReport,
+// because package level errors are created before main() is running,
+// DuplicatePanic also panics on the duplicate codes already recorded.
+func SetDuplicatePolicy(p DuplicatePolicy) {
+	reg.setDuplicatePolicy(p)
+}
+
+func (r *registry) setDuplicatePolicy(p DuplicatePolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.policy = p
+
+	if p == DuplicatePanic && len(r.duplicates) > 0 {
+		dup := r.duplicates[0]
+		panic(duplicateError(r.byCode[dup.Code], dup))
+	}
+}
+
+// Duplicates errors created with a code that already exists.
+func Duplicates() []*Error {
+	return reg.duplicateErrors()
+}
+
+func (r *registry) duplicateErrors() []*Error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.duplicates)
+}
+
+// CheckDuplicates returns ErrDuplicateCode with all the duplicate codes, e.g. for unit tests.
+func CheckDuplicates() error {
+	return reg.checkDuplicates()
+}
+
+func (r *registry) checkDuplicates() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.duplicates) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(r.duplicates))
+	for _, dup := range r.duplicates {
+		errs = append(errs, duplicateError(r.byCode[dup.Code], dup))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Lookup registered error by code.
+func Lookup(code int) (*Error, bool) {
+	return reg.lookup(code)
+}
+
+func (r *registry) lookup(code int) (*Error, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, ok := r.byCode[code]
+
+	return e, ok
+}
+
+// Registered all registered errors sorted by code.
+func Registered() []*Error {
+	reg.mu.RLock()
+	errs := slices.Clone(reg.errs)
+	reg.mu.RUnlock()
+
+	slices.SortFunc(errs, func(a, b *Error) int {
+		return a.Code - b.Code
+	})
+
+	return errs
+}
+
+// Catalog all registered errors sorted by code.
+func Catalog() []Entry {
+	errs := Registered()
+	es := make([]Entry, 0, len(errs))
+
+	for _, e := range errs {
+		es = append(es, Entry{
+			Code:             e.Code,
+			MsgID:            e.Message,
+			HTTPCode:         e.HTTPCode(),
+			ErrorLogDisabled: e.IsErrorLogDisabled(),
+		})
+	}
+
+	return es
+}
+
+func WriteCatalogJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(Catalog()); err != nil {
+		return fmt.Errorf("errcode.WriteCatalogJSON: %w", err)
+	}
+
+	return nil
+}
+
+func WriteCatalogMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("| Code | Message ID | HTTP Status | Error Log |\n")
+	b.WriteString("| ---: | --- | --- | --- |\n")
+
+	for _, e := range Catalog() {
+		errLog := "enabled"
+		if e.ErrorLogDisabled {
+			errLog = "disabled"
+		}
+
+		fmt.Fprintf(&b, "| %d | `%s` | %d %s | %s |\n",
+			e.Code, e.MsgID, e.HTTPCode, http.StatusText(e.HTTPCode), errLog)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("errcode.WriteCatalogMarkdown: %w", err)
+	}
+
+	return nil
+}
+
+func duplicateError(exist, dup *Error) error {
+	return fmt.Errorf("%w: code=%d %s, already used by %s",
+		ErrDuplicateCode, dup.Code, dup.Message, exist.Message)
+}
@@ -0,0 +1,77 @@
+package errcode
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	// Duplicates in the global registry break CheckDuplicates() of the other tests
+	r := newRegistry()
+
+	errFoo := NewUnregistered(900001, "ErrFoo", http.StatusForbidden)
+	errFooDup := NewUnregistered(900001, "ErrFooDup")
+	r.register(errFoo)
+	r.register(errFooDup)
+
+	e, ok := r.lookup(900001)
+	assert.True(t, ok)
+	assert.Same(t, errFoo, e)
+
+	assert.Contains(t, r.duplicateErrors(), errFooDup)
+	assert.ErrorIs(t, r.checkDuplicates(), ErrDuplicateCode)
+	assert.ErrorContains(t, r.checkDuplicates(), "code=900001 ErrFooDup, already used by ErrFoo")
+
+	NewUnregistered(900002, "ErrUnregistered")
+	_, ok = Lookup(900002)
+	assert.False(t, ok)
+
+	assert.PanicsWithError(t, r.checkDuplicates().Error(), func() {
+		r.setDuplicatePolicy(DuplicatePanic)
+	})
+	assert.Panics(t, func() {
+		r.register(NewUnregistered(900001, "ErrFooDup2"))
+	})
+}
+
+func TestCheckDuplicates(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, CheckDuplicates())
+}
+
+func TestCatalog(t *testing.T) {
+	t.Parallel()
+
+	es := Catalog()
+	for i := 1; i < len(es); i++ {
+		assert.Less(t, es[i-1].Code, es[i].Code)
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteCatalogJSON(&buf))
+
+	var got []Entry
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Contains(t, got, Entry{
+		Code:             ErrServiceTimeout.Code,
+		MsgID:            "ErrServiceTimeout",
+		HTTPCode:         http.StatusServiceUnavailable,
+		ErrorLogDisabled: true,
+	})
+
+	buf.Reset()
+	assert.NoError(t, WriteCatalogMarkdown(&buf))
+
+	md := buf.String()
+	assert.True(t, strings.HasPrefix(md, "| Code | Message ID | HTTP Status | Error Log |\n"))
+	assert.Contains(t, md, "| 1101 | `ErrServiceTimeout` | 503 Service Unavailable | disabled |\n")
+	assert.Contains(t, md, "| 404 | `ErrNotFound` | 404 Not Found | enabled |\n")
+}
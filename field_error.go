@@ -0,0 +1,55 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+var _ validator.FieldError = (*fieldError)(nil)
+
+// fieldError validation error not produced by the validator, e.g. pagination params,
+// so it can be translated and responded the same as validator.ValidationErrors.
+type fieldError struct {
+	tag   string
+	field string
+	value any
+	param string
+}
+
+func newFieldError(field, tag string, value any, param string) *fieldError {
+	return &fieldError{tag: tag, field: field, value: value, param: param}
+}
+
+func (fe *fieldError) Tag() string             { return fe.tag }
+func (fe *fieldError) ActualTag() string       { return fe.tag }
+func (fe *fieldError) Namespace() string       { return fe.field }
+func (fe *fieldError) StructNamespace() string { return fe.field }
+func (fe *fieldError) Field() string           { return fe.field }
+func (fe *fieldError) StructField() string     { return fe.field }
+func (fe *fieldError) Value() any              { return fe.value }
+func (fe *fieldError) Param() string           { return fe.param }
+
+func (fe *fieldError) Kind() reflect.Kind {
+	if fe.value == nil {
+		return reflect.Invalid
+	}
+
+	return reflect.TypeOf(fe.value).Kind()
+}
+
+//nolint:ireturn
+func (fe *fieldError) Type() reflect.Type {
+	return reflect.TypeOf(fe.value)
+}
+
+func (fe *fieldError) Translate(_ ut.Translator) string {
+	return fe.Error()
+}
+
+func (fe *fieldError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:Field validation for '%s' failed on the '%s' tag",
+		fe.field, fe.field, fe.tag)
+}
@@ -0,0 +1,294 @@
+package api
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const fieldPathCtxKey = "litsea.gin-api.field-path"
+
+var defaultFieldTags = []string{"json", "form", "uri", "header"}
+
+type FieldPathStyle int
+
+const (
+	// FieldPathDotted e.g. "items[3].address.zip".
+	FieldPathDotted FieldPathStyle = iota
+	// FieldPathJSONPointer RFC 6901 JSON pointer, e.g. "/items/3/address/zip".
+	FieldPathJSONPointer
+)
+
+type fieldPathConfig struct {
+	style FieldPathStyle
+	tags  []string
+}
+
+type FieldPathOption func(*fieldPathConfig)
+
+// WithFieldPathStyle default FieldPathDotted.
+func WithFieldPathStyle(s FieldPathStyle) FieldPathOption {
+	return func(c *fieldPathConfig) {
+		c.style = s
+	}
+}
+
+// WithFieldPathTags struct tags of the field names, the first non-empty one is used,
+// default "json", "form", "uri", "header", fallback to the struct field name.
+func WithFieldPathTags(tags ...string) FieldPathOption {
+	return func(c *fieldPathConfig) {
+		c.tags = tags
+	}
+}
+
+// FieldPathMiddleware the field of validation detail errors is the full path of the nested input
+// (e.g. "items[3].address.zip") instead of the field name (e.g. "zip").
+func FieldPathMiddleware(opts ...FieldPathOption) gin.HandlerFunc {
+	c := &fieldPathConfig{
+		tags: defaultFieldTags,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return func(ctx *gin.Context) {
+		ctx.Set(fieldPathCtxKey, c)
+		ctx.Next()
+	}
+}
+
+func getFieldPathConfig(ctx *gin.Context) *fieldPathConfig {
+	v, exists := ctx.Get(fieldPathCtxKey)
+	if !exists {
+		return nil
+	}
+
+	c, ok := v.(*fieldPathConfig)
+	if !ok {
+		return nil
+	}
+
+	return c
+}
+
+// crossFieldTags validation tags whose param is another field,
+// true if the param is the namespace from the root struct.
+var crossFieldTags = map[string]bool{
+	"eqfield": false, "nefield": false, "gtfield": false, "gtefield": false,
+	"ltfield": false, "ltefield": false, "fieldcontains": false, "fieldexcludes": false,
+	"eqcsfield": true, "necsfield": true, "gtcsfield": true, "gtecsfield": true,
+	"ltcsfield": true, "ltecsfield": true,
+}
+
+// pathSegment struct field name or slice/map index.
+type pathSegment struct {
+	name  string
+	index bool
+}
+
+// validationField name of the validation error field and the struct field of the req type,
+// the name is the full path when enabled by FieldPathMiddleware, otherwise the tag name.
+func validationField(ctx *gin.Context, fe validator.FieldError, req any) (string, reflect.StructField, bool) {
+	c := getFieldPathConfig(ctx)
+
+	sf, segs, ok := reqField(fe, req, fieldTags(ctx))
+	if !ok {
+		return fe.Field(), sf, false
+	}
+
+	if c != nil {
+		return formatPath(segs, c.style), sf, true
+	}
+
+	// The field name with the indexes, e.g. "tags[0]"
+	i := len(segs) - 1
+	for i > 0 && segs[i].index {
+		i--
+	}
+
+	return formatPath(segs[i:], FieldPathDotted), sf, true
+}
+
+// validationNamespace the path of tag names of the validation error field, e.g. "items[0].zip",
+// empty for struct level errors without a field.
+func validationNamespace(ctx *gin.Context, fe validator.FieldError, req any) string {
+	if fe.Field() == "" {
+		return ""
+	}
+
+	if _, segs, ok := reqField(fe, req, fieldTags(ctx)); ok {
+		return formatPath(segs, FieldPathDotted)
+	}
+
+	return fe.Field()
+}
+
+// fieldTags struct tags of the field names, see WithFieldPathTags().
+func fieldTags(ctx *gin.Context) []string {
+	if c := getFieldPathConfig(ctx); c != nil {
+		return c.tags
+	}
+
+	return defaultFieldTags
+}
+
+// reqField resolves the struct field of the validation error in the req type,
+// the struct field names in the namespace are mapped to the tag names.
+func reqField(fe validator.FieldError, req any, tags []string) (reflect.StructField, []pathSegment, bool) {
+	t, ns, ok := reqNamespace(fe, req)
+	if !ok {
+		return reflect.StructField{}, nil, false
+	}
+
+	return resolve(t, ns, tags)
+}
+
+// paramField resolves the struct field referenced by the param of cross-field tags, e.g. eqfield=Password.
+func paramField(fe validator.FieldError, req any, tags []string) (reflect.StructField, bool) {
+	crossStruct, ok := crossFieldTags[fe.Tag()]
+	if !ok || fe.Param() == "" {
+		return reflect.StructField{}, false
+	}
+
+	t, ns, ok := reqNamespace(fe, req)
+	if !ok {
+		return reflect.StructField{}, false
+	}
+
+	// Fields in the same struct, or the namespace from the root struct
+	ref := fe.Param()
+	if i := strings.LastIndex(ns, "."); !crossStruct && i >= 0 {
+		ref = ns[:i] + "." + ref
+	}
+
+	sf, _, ok := resolve(t, ref, tags)
+
+	return sf, ok
+}
+
+// reqNamespace the req struct type and the namespace without the root struct name,
+// e.g. "Items[3].Address.Zip" of "Req.Items[3].Address.Zip",
+// there is no root name for anonymous structs.
+func reqNamespace(fe validator.FieldError, req any) (reflect.Type, string, bool) {
+	t := indirectType(reflect.TypeOf(req))
+	if t == nil {
+		return nil, "", false
+	}
+
+	ns := fe.StructNamespace()
+	if t.Name() != "" {
+		var found bool
+		if ns, found = strings.CutPrefix(ns, t.Name()+"."); !found {
+			return nil, "", false
+		}
+	}
+
+	return t, ns, true
+}
+
+// jsonFieldPath the path of json.UnmarshalTypeError, e.g. "items.3.address.zip",
+// the field names are already the JSON names.
+func jsonFieldPath(ctx *gin.Context, field string) string {
+	c := getFieldPathConfig(ctx)
+	if c == nil || field == "" {
+		return field
+	}
+
+	names := strings.Split(field, ".")
+	segs := make([]pathSegment, len(names))
+
+	for i, name := range names {
+		_, err := strconv.Atoi(name)
+		segs[i] = pathSegment{name: name, index: err == nil}
+	}
+
+	return formatPath(segs, c.style)
+}
+
+func resolve(t reflect.Type, ns string, tags []string) (reflect.StructField, []pathSegment, bool) {
+	var (
+		f    reflect.StructField
+		segs []pathSegment
+	)
+
+	for part := range strings.SplitSeq(ns, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+
+		t = indirectType(t)
+		if t == nil || t.Kind() != reflect.Struct {
+			return f, nil, false
+		}
+
+		var ok bool
+		if f, ok = t.FieldByName(name); !ok {
+			return f, nil, false
+		}
+
+		// Embedded structs without a tag name are flattened
+		if n := fieldName(f, tags); !f.Anonymous || n != f.Name {
+			segs = append(segs, pathSegment{name: n})
+		}
+
+		t = f.Type
+
+		for rest != "" {
+			idx, next, _ := strings.Cut(rest, "]")
+			segs = append(segs, pathSegment{name: idx, index: true})
+			rest = strings.TrimPrefix(next, "[")
+
+			if t = indirectType(t); t != nil {
+				switch t.Kind() { //nolint:exhaustive
+				case reflect.Slice, reflect.Array, reflect.Map:
+					t = t.Elem()
+				}
+			}
+		}
+	}
+
+	return f, segs, len(segs) > 0
+}
+
+// fieldName the first non-empty tag name, fallback to the struct field name.
+func fieldName(f reflect.StructField, tags []string) string {
+	for _, tag := range tags {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return f.Name
+}
+
+func formatPath(segs []pathSegment, style FieldPathStyle) string {
+	var b strings.Builder
+
+	for i, s := range segs {
+		switch {
+		case style == FieldPathJSONPointer:
+			b.WriteString("/")
+			b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(s.name))
+		case s.index:
+			b.WriteString("[" + s.name + "]")
+		default:
+			if i > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(s.name)
+		}
+	}
+
+	return b.String()
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
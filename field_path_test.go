@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAddress struct {
+	Zip string `binding:"required,len=5" json:"zip"`
+}
+
+type testOrderItem struct {
+	Name    string       `binding:"required" json:"name"`
+	Address *testAddress `binding:"required" json:"address"`
+}
+
+type testOrderMeta struct {
+	Note string `binding:"max=3" json:"note"`
+}
+
+type testOrder struct {
+	testOrderMeta
+
+	Items []testOrderItem `binding:"required,dive" json:"items"`
+	Count int             `json:"count"`
+}
+
+func newFieldPathServer(mw ...gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(mw...)
+
+	r.POST("/order", func(ctx *gin.Context) {
+		req := &testOrder{}
+		if err := ctx.ShouldBindJSON(req); err != nil {
+			VError(ctx, err, req)
+
+			return
+		}
+
+		Success(ctx, nil)
+	})
+
+	return r
+}
+
+func TestFieldPath(t *testing.T) {
+	t.Parallel()
+
+	const (
+		invalidZip   = `{"items":[{"name":"a","address":{"zip":"12345"}},{"name":"b","address":{"zip":"1"}}]}`
+		invalidCount = `{"items":[],"count":"x"}`
+		invalidNote  = `{"items":[{"name":"a","address":{"zip":"12345"}}],"note":"long"}`
+	)
+
+	tests := []struct {
+		name string
+		mw   []gin.HandlerFunc
+		body string
+		want string
+	}{
+		{
+			name: "default",
+			body: invalidZip,
+			want: "zip",
+		},
+		{
+			name: "dotted",
+			mw:   []gin.HandlerFunc{FieldPathMiddleware()},
+			body: invalidZip,
+			want: "items[1].address.zip",
+		},
+		{
+			name: "json-pointer",
+			mw:   []gin.HandlerFunc{FieldPathMiddleware(WithFieldPathStyle(FieldPathJSONPointer))},
+			body: invalidZip,
+			want: "/items/1/address/zip",
+		},
+		{
+			name: "struct-field-name",
+			mw:   []gin.HandlerFunc{FieldPathMiddleware(WithFieldPathTags())},
+			body: invalidZip,
+			want: "Items[1].Address.Zip",
+		},
+		{
+			name: "embedded",
+			mw:   []gin.HandlerFunc{FieldPathMiddleware()},
+			body: invalidNote,
+			want: "note",
+		},
+		{
+			name: "json-type-error",
+			mw:   []gin.HandlerFunc{FieldPathMiddleware(WithFieldPathStyle(FieldPathJSONPointer))},
+			body: invalidCount,
+			want: "/count",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequestWithContext(context.Background(),
+				http.MethodPost, "/order", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			newFieldPathServer(tt.mw...).ServeHTTP(w, req)
+
+			var r Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &r))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			if assert.Len(t, r.Errors, 1) {
+				assert.Equal(t, tt.want, r.Errors[0].Field)
+			}
+		})
+	}
+}
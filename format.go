@@ -0,0 +1,75 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/litsea/gin-api/log"
+)
+
+const errorFormatterCtxKey = "litsea.gin-api.error-formatter"
+
+var (
+	_ ErrorFormatter = (*EnvelopeFormatter)(nil)
+	_ ErrorFormatter = (*ProblemFormatter)(nil)
+
+	defaultErrorFormatter ErrorFormatter = &EnvelopeFormatter{}
+)
+
+// ErrorFormatter converts an error Response into the body written to the client.
+// An empty content type keeps the default of the negotiated renderer.
+type ErrorFormatter interface {
+	Format(ctx *gin.Context, resp *Response) (contentType string, body any)
+}
+
+// EnvelopeFormatter renders errors as the default Response{code,msg,errors} envelope.
+type EnvelopeFormatter struct{}
+
+func (f *EnvelopeFormatter) Format(_ *gin.Context, resp *Response) (string, any) {
+	return "", resp
+}
+
+// ErrorFormat middleware selects the error response format for an engine or a route group.
+func ErrorFormat(f ErrorFormatter) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if f != nil {
+			SetErrorFormatterToContext(ctx, f)
+		}
+
+		ctx.Next()
+	}
+}
+
+// GetErrorFormatterFromContext get error formatter set by SetErrorFormatterToContext()
+//
+//nolint:ireturn
+func GetErrorFormatterFromContext(ctx *gin.Context) ErrorFormatter {
+	v, exists := ctx.Get(errorFormatterCtxKey)
+	if !exists {
+		return defaultErrorFormatter
+	}
+
+	f, ok := v.(ErrorFormatter)
+	if !ok {
+		return defaultErrorFormatter
+	}
+
+	return f
+}
+
+func SetErrorFormatterToContext(ctx *gin.Context, f ErrorFormatter) {
+	ctx.Set(errorFormatterCtxKey, f)
+}
+
+// writeError render the error response with the formatter of the current context.
+func writeError(ctx *gin.Context, resp *Response) {
+	log.SetErrorCodeToContext(ctx, resp.Code)
+
+	resp.TraceID = log.GetTraceID(ctx)
+
+	ct, body := GetErrorFormatterFromContext(ctx).Format(ctx, resp)
+	if ct != "" {
+		ctx.Header("Content-Type", ct)
+	}
+
+	renderResponse(ctx, resp.httpCode, body)
+}
@@ -32,7 +32,14 @@ func RouteRegisterPprof(r *gin.Engine, getTokenFn func() string) {
 		return
 	}
 
-	g := r.Group("/debug", func(ctx *gin.Context) {
+	g := r.Group("/debug", HandleDebugToken(getTokenFn))
+	pprof.RouteRegister(g, "pprof")
+}
+
+// HandleDebugToken protects the debug routes by the "token" parameter,
+// responds errcode.ErrNotFound if the token is empty, errcode.ErrForbidden if it does not match.
+func HandleDebugToken(getTokenFn func() string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
 		token := getTokenFn()
 		if token == "" {
 			Error(ctx, errcode.ErrNotFound)
@@ -50,6 +57,5 @@ func RouteRegisterPprof(r *gin.Engine, getTokenFn func() string) {
 			return
 		}
 		ctx.Next()
-	})
-	pprof.RouteRegister(g, "pprof")
+	}
 }
@@ -0,0 +1,349 @@
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+	"golang.org/x/text/language"
+
+	"github.com/litsea/gin-api/errcode"
+)
+
+var (
+	unmarshalFuncs = map[string]i18n.UnmarshalFunc{
+		"yaml": yaml.Unmarshal,
+		"yml":  yaml.Unmarshal,
+		"toml": toml.Unmarshal,
+	}
+
+	placeholderRegexp = regexp.MustCompile(`\{\{[^}]*?\.([A-Za-z_][A-Za-z0-9_]*)[^}]*}}`)
+)
+
+// Source localize files in the dir of fsys, e.g. Source{api.Localize, "localize"}.
+type Source struct {
+	FS  fs.FS
+	Dir string
+}
+
+type checker struct {
+	sources         []Source
+	languages       []language.Tag
+	defaultLanguage language.Tag
+	msgIDs          []string
+}
+
+type CheckOption func(*checker)
+
+func WithCheckSources(ss ...Source) CheckOption {
+	return func(c *checker) {
+		c.sources = append(c.sources, ss...)
+	}
+}
+
+// WithCheckLanguages default all languages found in the sources.
+func WithCheckLanguages(ls ...language.Tag) CheckOption {
+	return func(c *checker) {
+		c.languages = ls
+	}
+}
+
+// WithCheckDefaultLanguage placeholders are compared with the default language, default English.
+func WithCheckDefaultLanguage(lng language.Tag) CheckOption {
+	return func(c *checker) {
+		c.defaultLanguage = lng
+	}
+}
+
+// WithCheckMsgIDs additional message IDs which should be translated, e.g. "validation-<custom tag>".
+func WithCheckMsgIDs(ids ...string) CheckOption {
+	return func(c *checker) {
+		c.msgIDs = append(c.msgIDs, ids...)
+	}
+}
+
+type CheckReport struct {
+	Languages []*LanguageReport
+}
+
+type LanguageReport struct {
+	Language     language.Tag
+	Missing      []string              // Expected message IDs not translated
+	Extra        []string              // Translated message IDs not expected
+	Placeholders []PlaceholderMismatch // Placeholders different from the default language
+}
+
+type PlaceholderMismatch struct {
+	MsgID   string
+	Missing []string // Placeholders of the default language not used
+	Unknown []string // Placeholders not in the default language
+}
+
+// Check compares the translations in the sources with the message IDs of
+// the errcode registry, the validation tags and WithCheckMsgIDs().
+func Check(opts ...CheckOption) (*CheckReport, error) {
+	c := &checker{
+		defaultLanguage: language.English,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	msgs, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+
+	expected := c.expectedMsgIDs()
+
+	lngs := c.languages
+	if len(lngs) == 0 {
+		for lng := range msgs {
+			lngs = append(lngs, lng)
+		}
+		slices.SortFunc(lngs, func(a, b language.Tag) int {
+			return strings.Compare(a.String(), b.String())
+		})
+	}
+
+	r := &CheckReport{}
+
+	for _, lng := range lngs {
+		lr := &LanguageReport{Language: lng}
+		got := msgs[lng]
+
+		for _, id := range expected {
+			if _, ok := got[id]; !ok {
+				lr.Missing = append(lr.Missing, id)
+			}
+		}
+
+		for id := range got {
+			if !slices.Contains(expected, id) {
+				lr.Extra = append(lr.Extra, id)
+			}
+		}
+		slices.Sort(lr.Extra)
+
+		if lng != c.defaultLanguage {
+			lr.Placeholders = comparePlaceholders(msgs[c.defaultLanguage], got)
+		}
+
+		r.Languages = append(r.Languages, lr)
+	}
+
+	return r, nil
+}
+
+// HasIssues any language has missing, extra or placeholder mismatch message IDs.
+func (r *CheckReport) HasIssues() bool {
+	for _, lr := range r.Languages {
+		if lr.HasIssues() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (lr *LanguageReport) HasIssues() bool {
+	return len(lr.Missing) > 0 || len(lr.Extra) > 0 || len(lr.Placeholders) > 0
+}
+
+func (lr *LanguageReport) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "language %s:", lr.Language)
+
+	if len(lr.Missing) > 0 {
+		fmt.Fprintf(&b, "\n  missing: %s", strings.Join(lr.Missing, ", "))
+	}
+
+	if len(lr.Extra) > 0 {
+		fmt.Fprintf(&b, "\n  extra: %s", strings.Join(lr.Extra, ", "))
+	}
+
+	for _, p := range lr.Placeholders {
+		fmt.Fprintf(&b, "\n  placeholders of %s: missing=%v, unknown=%v", p.MsgID, p.Missing, p.Unknown)
+	}
+
+	return b.String()
+}
+
+// TestingT subset of testing.TB.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertTranslations reports the issues of each language by t.Errorf(), e.g.
+//
+//	i18n.AssertTranslations(t, i18n.WithCheckSources(i18n.Source{FS: api.Localize, Dir: "localize"}))
+func AssertTranslations(t TestingT, opts ...CheckOption) {
+	t.Helper()
+
+	r, err := Check(opts...)
+	if err != nil {
+		t.Errorf("i18n.AssertTranslations: %v", err)
+		return
+	}
+
+	for _, lr := range r.Languages {
+		if lr.HasIssues() {
+			t.Errorf("i18n.AssertTranslations: %s", lr)
+		}
+	}
+}
+
+// load messages of each language, all sources are merged.
+func (c *checker) load() (map[language.Tag]map[string]*i18n.Message, error) {
+	msgs := map[language.Tag]map[string]*i18n.Message{}
+
+	for _, s := range c.sources {
+		mfs, err := parseMessageFiles(s.FS, s.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("i18n.Check: %w", err)
+		}
+
+		for _, mf := range mfs {
+			if msgs[mf.Tag] == nil {
+				msgs[mf.Tag] = map[string]*i18n.Message{}
+			}
+
+			for _, m := range mf.Messages {
+				msgs[mf.Tag][m.ID] = m
+			}
+		}
+	}
+
+	// Messages of the registered rules are loaded by RuleLoader() or translated by V()
+	for lng := range msgs {
+		for _, m := range ruleMessages(lng) {
+			msgs[lng][m.ID] = m
+		}
+	}
+
+	return msgs, nil
+}
+
+func (c *checker) expectedMsgIDs() []string {
+	ids := ValidationMsgIDs()
+
+	for _, e := range errcode.Registered() {
+		ids = append(ids, e.Message)
+	}
+
+	ids = append(ids, c.msgIDs...)
+
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
+
+// parseMessageFiles parse the message files in the dir of fsys, the language is the file name, e.g. en.yaml.
+func parseMessageFiles(fsys fs.FS, dir string) ([]*i18n.MessageFile, error) {
+	dir = path.Clean(strings.TrimPrefix(dir, "./"))
+
+	es, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var mfs []*i18n.MessageFile
+
+	for _, e := range es {
+		if e.IsDir() || !isMessageFile(e.Name()) {
+			continue
+		}
+
+		p := path.Join(dir, e.Name())
+
+		buf, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return nil, err
+		}
+
+		mf, err := i18n.ParseMessageFileBytes(buf, p, unmarshalFuncs)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", p, err)
+		}
+
+		mfs = append(mfs, mf)
+	}
+
+	return mfs, nil
+}
+
+func isMessageFile(name string) bool {
+	ext := strings.TrimPrefix(path.Ext(name), ".")
+	if ext == "json" {
+		return true
+	}
+
+	_, ok := unmarshalFuncs[ext]
+
+	return ok
+}
+
+func comparePlaceholders(def, got map[string]*i18n.Message) []PlaceholderMismatch {
+	var ps []PlaceholderMismatch
+
+	ids := make([]string, 0, len(got))
+	for id := range got {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		dm, ok := def[id]
+		if !ok {
+			continue
+		}
+
+		want := placeholders(dm)
+		have := placeholders(got[id])
+
+		p := PlaceholderMismatch{MsgID: id}
+		for _, v := range want {
+			if !slices.Contains(have, v) {
+				p.Missing = append(p.Missing, v)
+			}
+		}
+		for _, v := range have {
+			if !slices.Contains(want, v) {
+				p.Unknown = append(p.Unknown, v)
+			}
+		}
+
+		if len(p.Missing) > 0 || len(p.Unknown) > 0 {
+			ps = append(ps, p)
+		}
+	}
+
+	return ps
+}
+
+// placeholders template fields of all plural forms, e.g. "field" of "{{.field}}",
+// "plural" is not a placeholder, languages without plural forms do not use it.
+func placeholders(m *i18n.Message) []string {
+	var vs []string
+
+	for _, s := range []string{m.Zero, m.One, m.Two, m.Few, m.Many, m.Other} {
+		for _, sm := range placeholderRegexp.FindAllStringSubmatch(s, -1) {
+			if sm[1] != pluralData {
+				vs = append(vs, sm[1])
+			}
+		}
+	}
+
+	slices.Sort(vs)
+
+	return slices.Compact(vs)
+}
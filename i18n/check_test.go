@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"localize/en.yaml": {Data: []byte(`
+ErrBadRequest: "Bad Request"
+validation-required: "{{.field}} must not be empty"
+validation-min-string:
+  one: "{{.field}} must be at least {{.value}} character in length"
+  other: "{{.field}} must be at least {{.value}} characters in length"
+ErrUnknown: "Unknown"
+`)},
+		"localize/de.json": {Data: []byte(`{
+  "ErrBadRequest": "Schlechte Anfrage",
+  "validation-required": "{{.name}} darf nicht leer sein",
+  "validation-min-string": "{{.field}} muss mindestens {{.value}} Zeichen lang sein"
+}`)},
+		"localize/README.md": {Data: []byte("ignored")},
+	}
+
+	r, err := Check(WithCheckSources(Source{FS: fsys, Dir: "./localize/"}))
+	assert.NoError(t, err)
+	assert.True(t, r.HasIssues())
+
+	if !assert.Len(t, r.Languages, 2) {
+		return
+	}
+
+	de, en := r.Languages[0], r.Languages[1]
+
+	assert.Equal(t, language.German, de.Language)
+	assert.Contains(t, de.Missing, "ErrNotFound")
+	assert.Contains(t, de.Missing, "validation-email")
+	assert.NotContains(t, de.Missing, "validation-required")
+	assert.Empty(t, de.Extra)
+	assert.Equal(t, []PlaceholderMismatch{
+		{MsgID: "validation-required", Missing: []string{"field"}, Unknown: []string{"name"}},
+	}, de.Placeholders)
+
+	assert.Equal(t, language.English, en.Language)
+	assert.Equal(t, []string{"ErrUnknown"}, en.Extra)
+	assert.Empty(t, en.Placeholders)
+	assert.NotContains(t, en.Missing, "validation-min-string")
+	assert.Contains(t, en.Missing, "validation-min-number")
+}
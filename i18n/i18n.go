@@ -2,6 +2,8 @@ package i18n
 
 import (
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -9,40 +11,249 @@ import (
 	i18n "github.com/litsea/gin-i18n"
 )
 
-// E translate error message.
-func E(ctx *gin.Context, msgID string) string {
-	return i18n.T(ctx, msgID, nil)
+// E translate error message, params are the template data, e.g. "{{.limit}}".
+func E(ctx *gin.Context, msgID string, params ...map[string]any) string {
+	if len(params) == 0 || len(params[0]) == 0 {
+		return i18n.T(ctx, msgID, nil)
+	}
+
+	data := make(map[any]any, len(params[0]))
+	for k, v := range params[0] {
+		data[k] = v
+	}
+
+	return i18n.T(ctx, msgID, data)
+}
+
+type vConfig struct {
+	name       string
+	namespace  *string
+	root       reflect.Type
+	label      string
+	paramName  string
+	paramLabel string
+	actual     string
+}
+
+type VOption func(*vConfig)
+
+// WithFieldName name of the field for "{{.field}}" without a label, e.g. the json tag name,
+// default the struct field name.
+func WithFieldName(name string) VOption {
+	return func(c *vConfig) {
+		c.name = name
+	}
 }
 
-// V translate validation error.
-func V(ctx *gin.Context, fe validator.FieldError) string {
+// WithNamespace path of the field for "{{.namespace}}", e.g. "items[0].zip",
+// default the struct namespace without the root struct name.
+func WithNamespace(ns string) VOption {
+	return func(c *vConfig) {
+		c.namespace = &ns
+	}
+}
+
+// WithRootStruct the validated struct or its pointer, the default "{{.namespace}}" is stripped of
+// its name, it is required for anonymous structs whose namespaces have no root struct name.
+func WithRootStruct(s any) VOption {
+	return func(c *vConfig) {
+		t := reflect.TypeOf(s)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		c.root = t
+	}
+}
+
+// WithFieldLabel display name of the field for "{{.field}}", translated if it is a message ID.
+func WithFieldLabel(label string) VOption {
+	return func(c *vConfig) {
+		c.label = label
+	}
+}
+
+// WithParamField display name of the field referenced by the param of cross-field tags (e.g. eqfield)
+// for "{{.value}}", the label is translated if it is a message ID, otherwise the name is used.
+func WithParamField(name, label string) VOption {
+	return func(c *vConfig) {
+		c.paramName = name
+		c.paramLabel = label
+	}
+}
+
+// WithActualValue the field value for "{{.actual}}", it is not exposed by default.
+// The value is rendered as is, escape and truncate the client input before.
+func WithActualValue(v string) VOption {
+	return func(c *vConfig) {
+		c.actual = v
+	}
+}
+
+// V translate validation error, template data:
+//   - field: the field label or name
+//   - value: the param, or the referenced field name or label of cross-field tags
+//   - param: the raw param of the tag
+//   - actual: the field value set by WithActualValue, empty by default
+//   - kind: the field kind, e.g. string, int, slice
+//   - namespace: the field path, e.g. Addresses[0].Zip, empty for struct level errors, see WithRootStruct()
+//   - plural: the CLDR plural category of the numeric param in the current language, e.g. one, other
+func V(ctx *gin.Context, fe validator.FieldError, opts ...VOption) string {
+	c := &vConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	field := fe.Field()
+	if c.name != "" {
+		field = c.name
+	}
+
+	if c.label != "" {
+		field = i18n.T(ctx, c.label, nil)
+	}
+
+	value := fe.Param()
+	switch {
+	case c.paramLabel != "":
+		value = i18n.T(ctx, c.paramLabel, nil)
+	case c.paramName != "":
+		value = c.paramName
+	}
+
+	ns := namespace(fe, c.root)
+	if c.namespace != nil {
+		ns = *c.namespace
+	}
+
+	data := map[any]any{
+		"field":     field,
+		"value":     value,
+		"param":     fe.Param(),
+		"actual":    c.actual,
+		"kind":      fe.Kind().String(),
+		"namespace": ns,
+		pluralData:  pluralForm(i18n.GetCurrentLanguage(ctx), fe.Param()),
+	}
+
 	msgID := genValidateMsgID(fe)
 
-	msg := i18n.T(ctx, genValidateMsgID(fe), map[any]any{
-		"field": fe.Field(),
-		"value": fe.Param(),
-	})
+	msg := i18n.T(ctx, msgID, data)
 	if msgID == msg {
-		msg = i18n.T(ctx, "validation-failed", map[any]any{
-			"field": fe.Field(),
-			"value": fe.Tag(),
-		})
+		if rm, ok := ruleMessage(ctx, msgID, data); ok {
+			return rm
+		}
+
+		data["value"] = fe.Tag()
+		msg = i18n.T(ctx, validationFailedMsgID, data)
 	}
 
 	return msg
 }
 
-var suffixTypes = map[string]struct{}{
-	"len": {}, "min": {}, "max": {},
-	"lt": {}, "lte": {}, "gt": {}, "gte": {},
+// namespace the struct namespace without the root struct name, e.g. Addresses[0].Zip of User.Addresses[0].Zip,
+// the Go type name of struct level errors without a field is not exposed.
+// Without the root struct type, the first segment is assumed to be the name of a named struct.
+func namespace(fe validator.FieldError, root reflect.Type) string {
+	if fe.Field() == "" {
+		return ""
+	}
+
+	if root != nil {
+		if root.Name() == "" {
+			return fe.Namespace()
+		}
+
+		return strings.TrimPrefix(fe.Namespace(), root.Name()+".")
+	}
+
+	if _, ns, found := strings.Cut(fe.Namespace(), "."); found {
+		return ns
+	}
+
+	return fe.Namespace()
+}
+
+// Field type suffixes of validation message IDs, e.g. validation-min-string.
+const (
+	KindString   = "string"
+	KindNumber   = "number"
+	KindItems    = "items"
+	KindDatetime = "datetime"
+
+	validationFailedMsgID = "validation-failed"
+)
+
+// suffixTypes tags with message IDs suffixed by the field type, e.g. validation-min-string,
+// custom tags are in ruleSuffixTypes.
+var suffixTypes = map[string][]string{
+	"len": {KindString, KindNumber, KindItems},
+	"min": {KindString, KindNumber, KindItems},
+	"max": {KindString, KindNumber, KindItems},
+	"lt":  {KindString, KindNumber, KindItems, KindDatetime},
+	"lte": {KindString, KindNumber, KindItems, KindDatetime},
+	"gt":  {KindString, KindNumber, KindItems, KindDatetime},
+	"gte": {KindString, KindNumber, KindItems, KindDatetime},
+}
+
+// validationTags tags translated by the built-in localize files, except suffixTypes.
+var validationTags = []string{
+	"required", "required_if", "required_unless", "required_with", "required_with_all",
+	"required_without", "required_without_all",
+	"excluded_if", "excluded_unless", "excluded_with", "excluded_with_all",
+	"excluded_without", "excluded_without_all",
+	"isdefault", "eq", "ne",
+	"eqfield", "eqcsfield", "necsfield", "gtcsfield", "gtecsfield", "ltcsfield", "ltecsfield",
+	"nefield", "gtfield", "gtefield", "ltfield", "ltefield",
+	"alpha", "alphanum", "numeric", "number", "hexadecimal", "hexcolor", "rgb", "rgba",
+	"email", "url", "uri", "base64",
+	"contains", "containsany", "excludes", "excludesall", "excludesrune",
+	"json", "jwt", "oneof", "lowercase", "uppercase", "datetime", "boolean",
+}
+
+// ValidationMsgIDs all validation message IDs which should be translated, including registered rules.
+func ValidationMsgIDs() []string {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	ids := []string{validationFailedMsgID}
+
+	for _, tag := range validationTags {
+		ids = append(ids, validateMsgID(tag, ""))
+	}
+
+	for tag, typs := range suffixTypes {
+		for _, typ := range typs {
+			ids = append(ids, validateMsgID(tag, typ))
+		}
+	}
+
+	ids = append(ids, ruleMsgIDs...)
+
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
+
+func validateMsgID(tag, typ string) string {
+	if typ == "" {
+		return "validation-" + tag
+	}
+
+	return "validation-" + tag + "-" + typ
 }
 
 func genValidateMsgID(fe validator.FieldError) string {
-	msgID := "validation-" + fe.Tag()
+	var typ string
 
-	if _, ok := suffixTypes[fe.Tag()]; ok {
-		var typ string
+	_, ok := suffixTypes[fe.Tag()]
+	if !ok {
+		rulesMu.RLock()
+		_, ok = ruleSuffixTypes[fe.Tag()]
+		rulesMu.RUnlock()
+	}
 
+	if ok {
 		kind := fe.Kind()
 		if kind == reflect.Ptr {
 			kind = fe.Type().Elem().Kind()
@@ -50,25 +261,21 @@ func genValidateMsgID(fe validator.FieldError) string {
 
 		switch kind { //nolint:exhaustive
 		case reflect.String:
-			typ = "string"
+			typ = KindString
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
 			reflect.Float32, reflect.Float64:
-			typ = "number"
+			typ = KindNumber
 		case reflect.Slice, reflect.Map, reflect.Array:
-			typ = "items"
+			typ = KindItems
 		case reflect.Struct:
 			if fe.Type() == reflect.TypeOf(time.Time{}) {
-				typ = "datetime"
+				typ = KindDatetime
 			}
 		default:
 			typ = ""
 		}
-
-		if typ != "" {
-			msgID += "-" + typ
-		}
 	}
 
-	return msgID
+	return validateMsgID(fe.Tag(), typ)
 }
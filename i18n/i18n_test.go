@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type testNamespaceReq struct {
+	Name    string `validate:"required"`
+	Address struct {
+		Zip string `validate:"required"`
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	t.Parallel()
+
+	anonymous := struct {
+		Name    string `validate:"required"`
+		Address struct {
+			Zip string `validate:"required"`
+		}
+	}{}
+
+	tests := []struct {
+		name string
+		req  any
+		root any
+		want []string
+	}{
+		{name: "named", req: testNamespaceReq{}, want: []string{"Name", "Address.Zip"}},
+		{name: "named-root", req: testNamespaceReq{}, root: &testNamespaceReq{}, want: []string{"Name", "Address.Zip"}},
+		{name: "anonymous-root", req: anonymous, root: anonymous, want: []string{"Name", "Address.Zip"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &vConfig{}
+			if tt.root != nil {
+				WithRootStruct(tt.root)(c)
+			}
+
+			var got []string
+			for _, fe := range validator.New().Struct(tt.req).(validator.ValidationErrors) { //nolint:errorlint,forcetypeassert
+				got = append(got, namespace(fe, c.root))
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
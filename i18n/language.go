@@ -0,0 +1,177 @@
+package i18n
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	i18n "github.com/litsea/gin-i18n"
+	"golang.org/x/text/language"
+)
+
+const defaultLanguageKey = "lang"
+
+type negotiator struct {
+	query  string
+	cookie string
+}
+
+type NegotiateOption func(*negotiator)
+
+// WithLanguageQuery query key to override the language, default "lang", empty to disable.
+func WithLanguageQuery(key string) NegotiateOption {
+	return func(n *negotiator) {
+		n.query = key
+	}
+}
+
+// WithLanguageCookie cookie name to override the language, default "lang", empty to disable.
+func WithLanguageCookie(name string) NegotiateOption {
+	return func(n *negotiator) {
+		n.cookie = name
+	}
+}
+
+// NegotiateLanguage language handler of gin-i18n, e.g.
+//
+//	gi18n.New(gi18n.WithGetLngHandler(i18n.NegotiateLanguage()), ...)
+//
+// The language is negotiated in order of the "lang" query, the "lang" cookie
+// and the Accept-Language header by q-value. Each requested language falls back
+// by script and region, e.g. zh-Hant-TW → zh-Hant → zh, finally to the default language.
+func NegotiateLanguage(opts ...NegotiateOption) i18n.GetLngHandler {
+	n := &negotiator{
+		query:  defaultLanguageKey,
+		cookie: defaultLanguageKey,
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n.negotiate
+}
+
+func (n *negotiator) negotiate(ctx *gin.Context) string {
+	def := i18n.GetDefaultLanguage(ctx)
+	if ctx == nil || ctx.Request == nil {
+		return def.String()
+	}
+
+	var ts []language.Tag
+
+	if n.query != "" {
+		if t, err := language.Parse(ctx.Query(n.query)); err == nil {
+			ts = append(ts, t)
+		}
+	}
+
+	if n.cookie != "" {
+		if v, err := ctx.Cookie(n.cookie); err == nil {
+			if t, err := language.Parse(v); err == nil {
+				ts = append(ts, t)
+			}
+		}
+	}
+
+	ts = append(ts, parseAcceptLanguage(ctx.GetHeader("Accept-Language"))...)
+
+	for _, t := range ts {
+		for _, ft := range fallbackChain(t) {
+			if i18n.HasLanguage(ctx, ft.String()) {
+				return ft.String()
+			}
+		}
+	}
+
+	return def.String()
+}
+
+// CurrentLanguage the language of the translations, the current language
+// if it is available, otherwise the default language.
+// Returns false without the gin-i18n middleware.
+func CurrentLanguage(ctx *gin.Context) (language.Tag, bool) {
+	def := i18n.GetDefaultLanguage(ctx)
+	if !i18n.HasLanguage(ctx, def.String()) {
+		return language.Und, false
+	}
+
+	lng := i18n.GetCurrentLanguage(ctx)
+	if !i18n.HasLanguage(ctx, lng.String()) {
+		return def, true
+	}
+
+	return lng, true
+}
+
+// parseAcceptLanguage languages of the Accept-Language header ordered by q-value,
+// invalid entries, "*" and q=0 are skipped.
+func parseAcceptLanguage(s string) []language.Tag {
+	type weighted struct {
+		tag language.Tag
+		q   float64
+	}
+
+	var ws []weighted
+
+	for _, entry := range strings.Split(s, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+
+		t, err := language.Parse(strings.TrimSpace(tag))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+
+		for _, p := range strings.Split(params, ";") {
+			k, v, ok := strings.Cut(strings.TrimSpace(p), "=")
+			if !ok || strings.TrimSpace(k) != "q" {
+				continue
+			}
+
+			if q, err = strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil {
+				q = 0
+			}
+		}
+
+		if q > 0 {
+			ws = append(ws, weighted{tag: t, q: q})
+		}
+	}
+
+	slices.SortStableFunc(ws, func(a, b weighted) int {
+		return cmp.Compare(b.q, a.q)
+	})
+
+	ts := make([]language.Tag, 0, len(ws))
+	for _, w := range ws {
+		ts = append(ts, w.tag)
+	}
+
+	return ts
+}
+
+// fallbackChain the language itself, then without the region, then without the script,
+// e.g. zh-Hant-TW → zh-Hant → zh, zh-TW → zh-Hant → zh.
+func fallbackChain(t language.Tag) []language.Tag {
+	ts := []language.Tag{t}
+
+	add := func(t language.Tag, err error) {
+		if err == nil && !slices.Contains(ts, t) {
+			ts = append(ts, t)
+		}
+	}
+
+	base, _ := t.Base()
+
+	if script, conf := t.Script(); conf != language.No {
+		add(language.Compose(base, script))
+	}
+
+	add(language.Compose(base))
+
+	return ts
+}
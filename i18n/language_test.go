@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	g18n "github.com/litsea/gin-i18n"
+	li18n "github.com/litsea/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestNegotiateLanguage(t *testing.T) {
+	t.Parallel()
+
+	zhHant := language.MustParse("zh-Hant")
+
+	gi := g18n.New(
+		g18n.WithGetLngHandler(NegotiateLanguage()),
+		g18n.WithOptions(
+			li18n.WithLanguages(language.English, language.German, language.Chinese, zhHant),
+			li18n.WithLoaders(li18n.LoaderFunc(func(lng language.Tag) (string, []byte, error) {
+				return lng.String() + ".yaml", []byte(`hello: "` + lng.String() + `"`), nil
+			})),
+		),
+	)
+
+	r := gin.New()
+	r.Use(gi.Localize())
+	r.GET("/", func(ctx *gin.Context) {
+		lng, _ := CurrentLanguage(ctx)
+		ctx.String(http.StatusOK, lng.String()+" "+E(ctx, "hello"))
+	})
+
+	tests := []struct {
+		name   string
+		uri    string
+		cookie string
+		accept string
+		want   string
+	}{
+		{name: "default", uri: "/", want: "en en"},
+		{name: "accept", uri: "/", accept: "de-DE", want: "de de"},
+		{name: "q-value", uri: "/", accept: "fr;q=0.9, de;q=0.5, zh;q=0.8", want: "zh zh"},
+		{name: "q-value-zero", uri: "/", accept: "de;q=0, *;q=0.5", want: "en en"},
+		{name: "region", uri: "/", accept: "zh-Hant-TW", want: "zh-Hant zh-Hant"},
+		{name: "region-script", uri: "/", accept: "zh-TW", want: "zh-Hant zh-Hant"},
+		{name: "script", uri: "/", accept: "zh-Hans-CN", want: "zh zh"},
+		{name: "invalid", uri: "/", accept: "!!, de", want: "de de"},
+		{name: "cookie", uri: "/", cookie: "zh", accept: "de", want: "zh zh"},
+		{name: "query", uri: "/?lang=de-AT", cookie: "zh", accept: "zh", want: "de de"},
+		{name: "query-unknown", uri: "/?lang=fr", accept: "de", want: "de de"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+			if tt.accept != "" {
+				req.Header.Set("Accept-Language", tt.accept)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
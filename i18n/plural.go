@@ -0,0 +1,41 @@
+package i18n
+
+import (
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// pluralData template data of the plural category, e.g. {{if eq .plural "one"}}item{{else}}items{{end}}.
+const pluralData = "plural"
+
+var pluralForms = map[plural.Form]string{
+	plural.Other: "other",
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+}
+
+// pluralForm CLDR cardinal plural category of the decimal number s in the language,
+// empty if s is not a number.
+func pluralForm(lng language.Tag, s string) string {
+	ip, fp, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	if ip == "" {
+		return ""
+	}
+
+	digits := make([]byte, 0, len(ip)+len(fp))
+
+	for _, c := range ip + fp {
+		if c < '0' || c > '9' {
+			return ""
+		}
+
+		digits = append(digits, byte(c-'0'))
+	}
+
+	return pluralForms[plural.Cardinal.MatchDigits(lng, digits, len(ip), len(fp))]
+}
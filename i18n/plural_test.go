@@ -0,0 +1,35 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestPluralForm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		lng  language.Tag
+		s    string
+		want string
+	}{
+		{lng: language.English, s: "1", want: "one"},
+		{lng: language.English, s: "3", want: "other"},
+		{lng: language.English, s: "1.5", want: "other"},
+		{lng: language.French, s: "0", want: "one"},
+		{lng: language.French, s: "2", want: "other"},
+		{lng: language.Japanese, s: "1", want: "other"},
+		{lng: language.English, s: "", want: ""},
+		{lng: language.English, s: "abc", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lng.String()+"/"+tt.s, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, pluralForm(tt.lng, tt.s))
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gin-gonic/gin"
+	g18n "github.com/litsea/gin-i18n"
+	li18n "github.com/litsea/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+
+	"github.com/litsea/gin-api/log"
+)
+
+const (
+	defaultReloadInterval = 2 * time.Second
+	reloadDebounce        = 100 * time.Millisecond
+	// configMapDataDir the symlink swapped by a Kubernetes ConfigMap volume update,
+	// the message files are symlinks to "..data/<file>" which are not changed themselves.
+	configMapDataDir = "..data"
+)
+
+var errNoMessageFile = errors.New("no message file")
+
+// Reloader gin-i18n middleware with the translations loaded from a directory,
+// the bundle used by E() and V() is swapped when the files are changed.
+type Reloader struct {
+	dir      string
+	options  []g18n.Option
+	i18nOpts []li18n.Option
+	loaders  []li18n.Loader
+	interval time.Duration
+	l        log.Logger
+	mu       sync.Mutex // Serializes reloads
+	handler  atomic.Pointer[gin.HandlerFunc]
+}
+
+type ReloadOption func(*Reloader)
+
+// WithReloadGinI18nOptions options of gin-i18n, e.g. g18n.WithGetLngHandler(), except g18n.WithOptions().
+func WithReloadGinI18nOptions(opts ...g18n.Option) ReloadOption {
+	return func(r *Reloader) {
+		r.options = append(r.options, opts...)
+	}
+}
+
+// WithReloadOptions options of the bundle, e.g. li18n.WithLanguages(), except li18n.WithLoaders().
+func WithReloadOptions(opts ...li18n.Option) ReloadOption {
+	return func(r *Reloader) {
+		r.i18nOpts = append(r.i18nOpts, opts...)
+	}
+}
+
+// WithReloadLoaders loaded before the files of the directory,
+// e.g. li18n.EmbedLoader(api.Localize, "./localize/"), the files override their messages.
+func WithReloadLoaders(ls ...li18n.Loader) ReloadOption {
+	return func(r *Reloader) {
+		r.loaders = append(r.loaders, ls...)
+	}
+}
+
+// WithReloadInterval polling interval when the file watcher is not available, default 2s.
+func WithReloadInterval(d time.Duration) ReloadOption {
+	return func(r *Reloader) {
+		if d > 0 {
+			r.interval = d
+		}
+	}
+}
+
+func WithReloadLogger(l log.Logger) ReloadOption {
+	return func(r *Reloader) {
+		if l != nil {
+			r.l = l
+		}
+	}
+}
+
+// NewReloader loads the YAML, JSON and TOML message files of dir, the language is the file name, e.g. en.yaml.
+func NewReloader(dir string, opts ...ReloadOption) (*Reloader, error) {
+	r := &Reloader{
+		dir:      dir,
+		interval: defaultReloadInterval,
+		l:        log.NewDisabled(), // default disabled
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, fmt.Errorf("i18n.NewReloader: %w", err)
+	}
+
+	return r, nil
+}
+
+// Localize middleware, use it instead of gin-i18n Localize().
+func (r *Reloader) Localize() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		(*r.handler.Load())(ctx)
+	}
+}
+
+// Reload validates the message files and swaps the bundle,
+// the previous bundle is kept if any file is invalid.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mfs, err := parseMessageFiles(os.DirFS(r.dir), ".")
+	if err != nil {
+		return fmt.Errorf("i18n.Reload: %w", err)
+	}
+
+	if err = validateTemplates(mfs); err != nil {
+		return fmt.Errorf("i18n.Reload: %w", err)
+	}
+
+	loaders := append(slices.Clone(r.loaders), messageFileLoader(mfs))
+	i18nOpts := append(slices.Clone(r.i18nOpts), li18n.WithLoaders(loaders...))
+	gi := g18n.New(append(slices.Clone(r.options), g18n.WithOptions(i18nOpts...))...)
+
+	h := gi.Localize()
+	r.handler.Store(&h)
+
+	return nil
+}
+
+// Watch reloads the translations when the files are changed until ctx is done,
+// the directory is polled if the file watcher is not available.
+// Reload errors are logged and the previous translations are kept.
+func (r *Reloader) Watch(ctx context.Context) {
+	w, err := fsnotify.NewWatcher()
+	if err == nil {
+		if err = w.Add(r.dir); err != nil {
+			_ = w.Close()
+		}
+	}
+
+	if err != nil {
+		r.l.Warn("i18n.Reloader.Watch: file watcher is not available, polling", "dir", r.dir, "err", err)
+		r.poll(ctx)
+
+		return
+	}
+
+	defer func() {
+		_ = w.Close()
+	}()
+
+	// Editors write a file by several events, reload once after them
+	timer := time.NewTimer(reloadDebounce)
+	timer.Stop()
+
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-w.Events:
+			if !ok {
+				return
+			}
+
+			if isReloadEvent(ev) {
+				timer.Reset(reloadDebounce)
+			}
+		case err, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+
+			r.l.Warn("i18n.Reloader.Watch: file watcher error", "dir", r.dir, "err", err)
+		case <-timer.C:
+			r.reload()
+		}
+	}
+}
+
+func (r *Reloader) poll(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	last := r.snapshot()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if s := r.snapshot(); s != last {
+				last = s
+				r.reload()
+			}
+		}
+	}
+}
+
+func (r *Reloader) reload() {
+	if err := r.Reload(); err != nil {
+		r.l.Error("i18n.Reloader: reload failed, keep the previous translations", "dir", r.dir, "err", err)
+		return
+	}
+
+	r.l.Info("i18n.Reloader: translations reloaded", "dir", r.dir)
+}
+
+// isReloadEvent changes of the message files or the ConfigMap data symlink.
+func isReloadEvent(ev fsnotify.Event) bool {
+	if ev.Has(fsnotify.Chmod) {
+		return false
+	}
+
+	name := filepath.Base(ev.Name)
+
+	return isMessageFile(name) || name == configMapDataDir
+}
+
+// snapshot names, sizes and modification times of the message files, symlinks are resolved.
+func (r *Reloader) snapshot() string {
+	es, err := os.ReadDir(r.dir)
+	if err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+
+	for _, e := range es {
+		if e.IsDir() || !isMessageFile(e.Name()) {
+			continue
+		}
+
+		fi, err := os.Stat(filepath.Join(r.dir, e.Name()))
+		if err != nil {
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s:%d:%d;", e.Name(), fi.Size(), fi.ModTime().UnixNano())
+	}
+
+	return b.String()
+}
+
+// validateTemplates parse the templates of all plural forms, which are parsed lazily by the bundle.
+func validateTemplates(mfs []*goi18n.MessageFile) error {
+	for _, mf := range mfs {
+		for _, m := range mf.Messages {
+			for _, s := range []string{m.Zero, m.One, m.Two, m.Few, m.Many, m.Other} {
+				if _, err := template.New(m.ID).Parse(s); err != nil {
+					return fmt.Errorf("%s: %w", mf.Path, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// messageFileLoader loads the parsed message files of the language.
+type messageFileLoader []*goi18n.MessageFile
+
+func (mfs messageFileLoader) LoadMessage(bd *goi18n.Bundle, lng language.Tag) error {
+	loaded := false
+
+	for _, mf := range mfs {
+		if mf.Tag != lng {
+			continue
+		}
+
+		if err := bd.AddMessages(lng, mf.Messages...); err != nil {
+			return fmt.Errorf("i18n.Reloader: %w", err)
+		}
+
+		loaded = true
+	}
+
+	if !loaded {
+		return fmt.Errorf("i18n.Reloader: %w: %s", errNoMessageFile, lng)
+	}
+
+	return nil
+}
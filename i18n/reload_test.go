@@ -0,0 +1,151 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	li18n "github.com/litsea/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestReloader(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, data string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600))
+	}
+
+	write("en.yaml", `hello: "Hello {{.name}}"`)
+	write("de.toml", `hello = "Hallo {{.name}}"`)
+
+	rl, err := NewReloader(dir,
+		WithReloadOptions(li18n.WithLanguages(language.English, language.German)),
+		WithReloadInterval(10*time.Millisecond),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	r := gin.New()
+	r.Use(rl.Localize())
+	r.GET("/", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, E(ctx, "hello", map[string]any{"name": "Gin"}))
+	})
+
+	get := func(lng language.Tag) string {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", http.NoBody)
+		req.Header.Set("Accept-Language", lng.String())
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		return w.Body.String()
+	}
+
+	assert.Equal(t, "Hello Gin", get(language.English))
+	assert.Equal(t, "Hallo Gin", get(language.German))
+
+	write("en.yaml", `hello: "Hi {{.name}}"`)
+	assert.NoError(t, rl.Reload())
+	assert.Equal(t, "Hi Gin", get(language.English))
+
+	// Invalid files keep the previous bundle
+	write("en.yaml", `hello: [`)
+	assert.Error(t, rl.Reload())
+	write("en.yaml", `hello: "Hi {{.name"`)
+	assert.Error(t, rl.Reload())
+	assert.Equal(t, "Hi Gin", get(language.English))
+
+	watch := func(fn func(ctx context.Context), want string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go fn(ctx)
+
+		// Wait for the watcher to be ready
+		time.Sleep(50 * time.Millisecond)
+
+		write("en.yaml", `hello: "`+want+` {{.name}}"`)
+		assert.Eventually(t, func() bool {
+			return get(language.English) == want+" Gin"
+		}, 2*time.Second, 10*time.Millisecond)
+	}
+
+	watch(rl.Watch, "Hey")
+	watch(rl.poll, "Yo")
+
+	_, err = NewReloader(filepath.Join(dir, "not-exists"))
+	assert.Error(t, err)
+}
+
+func TestReloaderConfigMap(t *testing.T) {
+	t.Parallel()
+
+	// Kubernetes ConfigMap volume: en.yaml -> ..data/en.yaml, ..data -> ..<timestamp>
+	dir := t.TempDir()
+	version := 0
+	update := func(data string) {
+		version++
+		ts := fmt.Sprintf("..v%d", version)
+
+		assert.NoError(t, os.Mkdir(filepath.Join(dir, ts), 0o700))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, ts, "en.yaml"), []byte(data), 0o600))
+		assert.NoError(t, os.Symlink(ts, filepath.Join(dir, "..data_tmp")))
+		assert.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	}
+
+	update(`hello: "Hello {{.name}}"`)
+	assert.NoError(t, os.Symlink(filepath.Join("..data", "en.yaml"), filepath.Join(dir, "en.yaml")))
+
+	rl, err := NewReloader(dir,
+		WithReloadOptions(li18n.WithLanguages(language.English)),
+		WithReloadInterval(10*time.Millisecond),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	r := gin.New()
+	r.Use(rl.Localize())
+	r.GET("/", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, E(ctx, "hello", map[string]any{"name": "Gin"}))
+	})
+
+	get := func() string {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", http.NoBody)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		return w.Body.String()
+	}
+
+	assert.Equal(t, "Hello Gin", get())
+
+	watch := func(fn func(ctx context.Context), want string) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go fn(ctx)
+
+		// Wait for the watcher to be ready
+		time.Sleep(50 * time.Millisecond)
+
+		update(`hello: "` + want + ` {{.name}}"`)
+		assert.Eventually(t, func() bool {
+			return get() == want+" Gin"
+		}, 2*time.Second, 10*time.Millisecond)
+	}
+
+	watch(rl.Watch, "Hey")
+	watch(rl.poll, "Howdy")
+}
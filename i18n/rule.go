@@ -0,0 +1,185 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	g18n "github.com/litsea/gin-i18n"
+	li18n "github.com/litsea/i18n"
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+var (
+	errRuleValidatorEngine = errors.New("gin binding validator is not *validator.Validate")
+	errRuleNoMessages      = errors.New("no rule messages")
+
+	rulesMu    sync.RWMutex
+	ruleMsgs   = map[language.Tag]map[string]*goi18n.Message{}
+	ruleMsgIDs []string
+	// ruleSuffixTypes kinds of the registered rules with messages per field kind, see suffixTypes.
+	ruleSuffixTypes = map[string][]string{}
+)
+
+type rule struct {
+	validate *validator.Validate
+	msgs     map[language.Tag]map[string]string // language => kind => template
+	nullable bool
+}
+
+type RuleOption func(*rule)
+
+// WithRuleMessage message template of the language, e.g. "{{.field}} must be a valid phone number".
+func WithRuleMessage(lng language.Tag, tpl string) RuleOption {
+	return WithRuleKindMessage("", lng, tpl)
+}
+
+// WithRuleKindMessage message template of the language for the field kind
+// (KindString, KindNumber, KindItems or KindDatetime), e.g. validation-<tag>-string.
+func WithRuleKindMessage(kind string, lng language.Tag, tpl string) RuleOption {
+	return func(r *rule) {
+		if r.msgs[lng] == nil {
+			r.msgs[lng] = map[string]string{}
+		}
+
+		r.msgs[lng][kind] = tpl
+	}
+}
+
+// WithRuleValidator default the validator engine of gin binding.
+func WithRuleValidator(v *validator.Validate) RuleOption {
+	return func(r *rule) {
+		r.validate = v
+	}
+}
+
+// WithRuleCallEvenIfNull call the validation func even if the field is nil.
+func WithRuleCallEvenIfNull(v bool) RuleOption {
+	return func(r *rule) {
+		r.nullable = v
+	}
+}
+
+// RegisterRule registers the validation tag and its messages, it can be called before or after
+// creating the i18n bundle, messages which are not in the bundle are translated by V() directly.
+func RegisterRule(tag string, fn validator.Func, opts ...RuleOption) error {
+	r := &rule{
+		msgs: map[language.Tag]map[string]string{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.validate == nil {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return fmt.Errorf("i18n.RegisterRule: %w", errRuleValidatorEngine)
+		}
+
+		r.validate = v
+	}
+
+	if err := r.validate.RegisterValidation(tag, fn, r.nullable); err != nil {
+		return fmt.Errorf("i18n.RegisterRule: %w", err)
+	}
+
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+
+	for lng, tpls := range r.msgs {
+		if ruleMsgs[lng] == nil {
+			ruleMsgs[lng] = map[string]*goi18n.Message{}
+		}
+
+		for kind, tpl := range tpls {
+			id := validateMsgID(tag, kind)
+			ruleMsgs[lng][id] = &goi18n.Message{ID: id, Other: tpl}
+
+			if !slices.Contains(ruleMsgIDs, id) {
+				ruleMsgIDs = append(ruleMsgIDs, id)
+			}
+
+			if kind != "" && !slices.Contains(ruleSuffixTypes[tag], kind) {
+				ruleSuffixTypes[tag] = append(ruleSuffixTypes[tag], kind)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RuleLoader optional, loads the messages of the rules registered before creating the i18n bundle
+// into it, e.g.
+//
+//	g18n.New(g18n.WithOptions(li18n.WithLoaders(li18n.EmbedLoader(api.Localize, "./localize/"), i18n.RuleLoader())))
+//
+//nolint:ireturn
+func RuleLoader() li18n.Loader {
+	return ruleLoader{}
+}
+
+type ruleLoader struct{}
+
+func (ruleLoader) LoadMessage(bd *goi18n.Bundle, lng language.Tag) error {
+	msgs := ruleMessages(lng)
+	if len(msgs) == 0 {
+		return fmt.Errorf("i18n.RuleLoader: %w: %s", errRuleNoMessages, lng)
+	}
+
+	if err := bd.AddMessages(lng, msgs...); err != nil {
+		return fmt.Errorf("i18n.RuleLoader: %w", err)
+	}
+
+	return nil
+}
+
+// ruleMessages messages of the registered rules for the language.
+func ruleMessages(lng language.Tag) []*goi18n.Message {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	msgs := make([]*goi18n.Message, 0, len(ruleMsgs[lng]))
+	for _, m := range ruleMsgs[lng] {
+		msgs = append(msgs, m)
+	}
+
+	return msgs
+}
+
+// ruleMessage translates the message of the registered rule which is not in the i18n bundle,
+// in the current language, otherwise the default language.
+func ruleMessage(ctx *gin.Context, msgID string, data map[any]any) (string, bool) {
+	var m *goi18n.Message
+
+	rulesMu.RLock()
+	for _, lng := range []language.Tag{g18n.GetCurrentLanguage(ctx), g18n.GetDefaultLanguage(ctx)} {
+		if m = ruleMsgs[lng][msgID]; m != nil {
+			break
+		}
+	}
+	rulesMu.RUnlock()
+
+	if m == nil {
+		return "", false
+	}
+
+	tpl, err := template.New(msgID).Parse(m.Other)
+	if err != nil {
+		return "", false
+	}
+
+	var b strings.Builder
+	if err := tpl.Execute(&b, data); err != nil {
+		return "", false
+	}
+
+	return b.String(), true
+}
@@ -0,0 +1,174 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	g18n "github.com/litsea/gin-i18n"
+	li18n "github.com/litsea/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestRegisterRule(t *testing.T) {
+	t.Parallel()
+
+	v := validator.New()
+	slug := regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+	err := RegisterRule("test_slug", func(fl validator.FieldLevel) bool {
+		return slug.MatchString(fl.Field().String())
+	},
+		WithRuleValidator(v),
+		WithRuleMessage(language.English, "{{.field}} must be a valid slug"),
+		WithRuleMessage(language.German, "{{.field}} muss ein gültiger Slug sein"),
+	)
+	assert.NoError(t, err)
+
+	err = RegisterRule("test_even", func(fl validator.FieldLevel) bool {
+		return fl.Field().Len()%2 == 0
+	},
+		WithRuleValidator(v),
+		WithRuleKindMessage(KindString, language.English, "{{.field}} must have an even length"),
+		WithRuleKindMessage(KindItems, language.English, "{{.field}} must contain an even number of items"),
+	)
+	assert.NoError(t, err)
+
+	assert.Subset(t, ValidationMsgIDs(), []string{
+		"validation-test_slug", "validation-test_even-string", "validation-test_even-items",
+	})
+
+	gi := g18n.New(g18n.WithOptions(
+		li18n.WithLanguages(language.English, language.German),
+		li18n.WithLoaders(RuleLoader()),
+	))
+
+	r := gin.New()
+	r.Use(gi.Localize())
+	r.GET("/", func(ctx *gin.Context) {
+		req := struct {
+			Slug string   `validate:"test_slug"`
+			Name string   `validate:"test_even"`
+			Tags []string `validate:"test_even"`
+		}{"Not A Slug", "abc", []string{"a"}}
+
+		var msgs []string
+		for _, fe := range v.Struct(req).(validator.ValidationErrors) { //nolint:errorlint,forcetypeassert
+			msgs = append(msgs, V(ctx, fe))
+		}
+
+		ctx.JSON(http.StatusOK, msgs)
+	})
+
+	tests := []struct {
+		lng  language.Tag
+		want string
+	}{
+		{
+			lng: language.English,
+			want: `["Slug must be a valid slug","Name must have an even length",` +
+				`"Tags must contain an even number of items"]`,
+		},
+		{
+			lng: language.German,
+			want: `["Slug muss ein gültiger Slug sein","Name must have an even length",` +
+				`"Tags must contain an even number of items"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", http.NoBody)
+		req.Header.Set("Accept-Language", tt.lng.String())
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.JSONEq(t, tt.want, w.Body.String())
+	}
+
+	report, err := Check(
+		WithCheckSources(Source{FS: fstest.MapFS{"localize/de.yaml": {Data: []byte(`ErrBadRequest: "x"`)}}, Dir: "localize"}),
+	)
+	assert.NoError(t, err)
+	assert.NotContains(t, report.Languages[0].Missing, "validation-test_slug")
+	assert.Contains(t, report.Languages[0].Missing, "validation-test_even-string")
+}
+
+func TestRegisterRuleAfterBundle(t *testing.T) {
+	t.Parallel()
+
+	v := validator.New()
+
+	gi := g18n.New(g18n.WithOptions(
+		li18n.WithLanguages(language.English, language.German),
+		li18n.WithLoaders(li18n.LoaderFunc(func(lng language.Tag) (string, []byte, error) {
+			msgs := map[language.Tag]string{
+				language.English: `validation-failed: "{{.field}} failed on {{.value}}"`,
+				language.German:  `validation-failed: "{{.field}} ist ungültig ({{.value}})"`,
+			}
+
+			return lng.String() + ".yaml", []byte(msgs[lng]), nil
+		})),
+	))
+
+	// Registered after creating the bundle without RuleLoader()
+	err := RegisterRule("test_odd", func(fl validator.FieldLevel) bool {
+		return len(fmt.Sprint(fl.Field().Interface()))%2 == 1
+	},
+		WithRuleValidator(v),
+		WithRuleKindMessage(KindString, language.English, "{{.field}} must have an odd length"),
+		WithRuleKindMessage(KindString, language.German, "{{.field}} muss eine ungerade Länge haben"),
+		WithRuleKindMessage(KindItems, language.English, "{{.field}} must contain an odd number of items"),
+	)
+	assert.NoError(t, err)
+
+	r := gin.New()
+	r.Use(gi.Localize())
+	r.GET("/", func(ctx *gin.Context) {
+		req := struct {
+			Name string   `validate:"test_odd"`
+			Tags []string `validate:"test_odd"`
+			Size int      `validate:"test_odd"`
+		}{"ab", []string{"a", "bc"}, 10}
+
+		var msgs []string
+		for _, fe := range v.Struct(req).(validator.ValidationErrors) { //nolint:errorlint,forcetypeassert
+			msgs = append(msgs, V(ctx, fe))
+		}
+
+		ctx.JSON(http.StatusOK, msgs)
+	})
+
+	tests := []struct {
+		lng  language.Tag
+		want string
+	}{
+		{
+			lng: language.English,
+			want: `["Name must have an odd length","Tags must contain an odd number of items",` +
+				`"Size failed on test_odd"]`,
+		},
+		{
+			lng: language.German,
+			want: `["Name muss eine ungerade Länge haben","Tags must contain an odd number of items",` +
+				`"Size ist ungültig (test_odd)"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", http.NoBody)
+		req.Header.Set("Accept-Language", tt.lng.String())
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.JSONEq(t, tt.want, w.Body.String())
+	}
+}
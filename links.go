@@ -0,0 +1,232 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	pageLinksCtxKey = "litsea.gin-api.page-links"
+
+	pageLinksParamPage   = "page"
+	pageLinksParamCursor = "cursor"
+)
+
+// PageLinks RFC 8288 pagination links.
+type PageLinks struct {
+	First string `json:"first,omitempty" xml:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"  xml:"prev,omitempty"`
+	Next  string `json:"next,omitempty"  xml:"next,omitempty"`
+	Last  string `json:"last,omitempty"  xml:"last,omitempty"`
+}
+
+type pageLinksConfig struct {
+	baseURL string
+	header  bool
+	body    bool
+}
+
+type PageLinksOption func(*pageLinksConfig)
+
+// WithPageLinksBaseURL absolute links with the base URL (e.g. "https://api.example.com"),
+// default links are relative to the host.
+func WithPageLinksBaseURL(u string) PageLinksOption {
+	return func(c *pageLinksConfig) {
+		c.baseURL = strings.TrimRight(u, "/")
+	}
+}
+
+// WithPageLinksHeader emit the Link header, default true.
+func WithPageLinksHeader(v bool) PageLinksOption {
+	return func(c *pageLinksConfig) {
+		c.header = v
+	}
+}
+
+// WithPageLinksBody add the links object to the response body, default true.
+func WithPageLinksBody(v bool) PageLinksOption {
+	return func(c *pageLinksConfig) {
+		c.body = v
+	}
+}
+
+// PageLinksMiddleware PageSuccess and CursorPageSuccess emit first/prev/next/last links
+// computed from the current request URL for an engine or a route group.
+func PageLinksMiddleware(opts ...PageLinksOption) gin.HandlerFunc {
+	c := &pageLinksConfig{
+		header: true,
+		body:   true,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return func(ctx *gin.Context) {
+		ctx.Set(pageLinksCtxKey, c)
+		ctx.Next()
+	}
+}
+
+func getPageLinksConfig(ctx *gin.Context) *pageLinksConfig {
+	v, exists := ctx.Get(pageLinksCtxKey)
+	if !exists {
+		return nil
+	}
+
+	c, ok := v.(*pageLinksConfig)
+	if !ok {
+		return nil
+	}
+
+	return c
+}
+
+// pageLinks links of page number pagination, nil if links are not enabled in the body.
+func pageLinks(ctx *gin.Context, total int64, size, page int) *PageLinks {
+	c := getPageLinksConfig(ctx)
+	if c == nil || size <= 0 {
+		return nil
+	}
+
+	last := int((total + int64(size) - 1) / int64(size))
+	if last < 1 {
+		last = 1
+	}
+
+	link := func(p int) string {
+		return c.link(ctx, pageLinksParamPage, strconv.Itoa(p))
+	}
+
+	l := &PageLinks{
+		First: link(1),
+		Last:  link(last),
+	}
+
+	if page > 1 {
+		l.Prev = link(min(page-1, last))
+	}
+
+	if page < last {
+		l.Next = link(page + 1)
+	}
+
+	return c.write(ctx, l)
+}
+
+// cursorPageLinks links of cursor pagination, nil if links are not enabled in the body.
+func cursorPageLinks(ctx *gin.Context, next any) *PageLinks {
+	c := getPageLinksConfig(ctx)
+	if c == nil {
+		return nil
+	}
+
+	l := &PageLinks{
+		First: c.link(ctx, pageLinksParamCursor, ""),
+	}
+
+	if cur, ok := cursorParam(next); ok {
+		l.Next = c.link(ctx, pageLinksParamCursor, cur)
+	}
+
+	return c.write(ctx, l)
+}
+
+// cursorParam the cursor as the query param, false for the zero cursor (last page)
+// and the cursors which are not strings or integers, e.g. structs without a cursor codec.
+func cursorParam(v any) (string, bool) {
+	if isZeroCursor(v) {
+		return "", false
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return rv.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), true
+	default:
+		return "", false
+	}
+}
+
+// link current request URL with the original query string, an empty value removes the param.
+func (c *pageLinksConfig) link(ctx *gin.Context, param, value string) string {
+	u := url.URL{
+		Path:     ctx.Request.URL.Path,
+		RawQuery: replaceQueryParam(ctx.Request.URL.RawQuery, param, value),
+	}
+
+	return c.baseURL + u.String()
+}
+
+// replaceQueryParam replaces the first param of the raw query in place or appends it, removes the others,
+// the other params keep their order and encoding.
+func replaceQueryParam(rawQuery, param, value string) string {
+	pairs := strings.Split(rawQuery, "&")
+	qs := make([]string, 0, len(pairs)+1)
+	replaced := value == ""
+
+	for _, pair := range pairs {
+		if pair == "" {
+			continue
+		}
+
+		k, _, _ := strings.Cut(pair, "=")
+		if key, err := url.QueryUnescape(k); err != nil || key != param {
+			qs = append(qs, pair)
+			continue
+		}
+
+		if !replaced {
+			qs = append(qs, param+"="+url.QueryEscape(value))
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		qs = append(qs, param+"="+url.QueryEscape(value))
+	}
+
+	return strings.Join(qs, "&")
+}
+
+func (c *pageLinksConfig) write(ctx *gin.Context, l *PageLinks) *PageLinks {
+	if c.header {
+		ctx.Writer.Header().Add("Link", l.header())
+	}
+
+	if !c.body {
+		return nil
+	}
+
+	return l
+}
+
+func (l *PageLinks) header() string {
+	rels := []struct {
+		rel  string
+		link string
+	}{
+		{"first", l.First},
+		{"prev", l.Prev},
+		{"next", l.Next},
+		{"last", l.Last},
+	}
+
+	vs := make([]string, 0, len(rels))
+	for _, r := range rels {
+		if r.link != "" {
+			vs = append(vs, fmt.Sprintf(`<%s>; rel="%s"`, r.link, r.rel))
+		}
+	}
+
+	return strings.Join(vs, ", ")
+}
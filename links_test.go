@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLinksServer(opts ...PageLinksOption) *gin.Engine {
+	r := gin.New()
+	r.Use(PageLinksMiddleware(opts...), CursorCodecMiddleware(testCursorCodec))
+
+	r.GET("/users", func(ctx *gin.Context) {
+		req, ok := BindPage(ctx)
+		if !ok {
+			return
+		}
+
+		PageSuccess(ctx, 45, req.Size, req.Page, []int{1})
+	})
+
+	r.GET("/events", func(ctx *gin.Context) {
+		var next *testCursor
+		if ctx.Query("cursor") == "" {
+			next = &testCursor{ID: 1}
+		}
+
+		CursorPageSuccess(ctx, 45, 10, nil, next, []int{1})
+	})
+
+	return r
+}
+
+func TestPageLinks(t *testing.T) {
+	t.Parallel()
+
+	next, err := testCursorCodec.Encode(&testCursor{ID: 1})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		opts       []PageLinksOption
+		uri        string
+		wantHeader string
+		wantLinks  *PageLinks
+	}{
+		{
+			name: "first-page",
+			uri:  "/users?size=20&q=foo",
+			wantHeader: `</users?size=20&q=foo&page=1>; rel="first", ` +
+				`</users?size=20&q=foo&page=2>; rel="next", ` +
+				`</users?size=20&q=foo&page=3>; rel="last"`,
+			wantLinks: &PageLinks{
+				First: "/users?size=20&q=foo&page=1",
+				Next:  "/users?size=20&q=foo&page=2",
+				Last:  "/users?size=20&q=foo&page=3",
+			},
+		},
+		{
+			name: "unsorted-repeated-params",
+			uri:  "/users?z=1&a=2&size=20&page=2&tag=a%20b&tag=c+d&page=9",
+			wantHeader: `</users?z=1&a=2&size=20&page=1&tag=a%20b&tag=c+d>; rel="first", ` +
+				`</users?z=1&a=2&size=20&page=1&tag=a%20b&tag=c+d>; rel="prev", ` +
+				`</users?z=1&a=2&size=20&page=3&tag=a%20b&tag=c+d>; rel="next", ` +
+				`</users?z=1&a=2&size=20&page=3&tag=a%20b&tag=c+d>; rel="last"`,
+			wantLinks: &PageLinks{
+				First: "/users?z=1&a=2&size=20&page=1&tag=a%20b&tag=c+d",
+				Prev:  "/users?z=1&a=2&size=20&page=1&tag=a%20b&tag=c+d",
+				Next:  "/users?z=1&a=2&size=20&page=3&tag=a%20b&tag=c+d",
+				Last:  "/users?z=1&a=2&size=20&page=3&tag=a%20b&tag=c+d",
+			},
+		},
+		{
+			name: "middle-page",
+			opts: []PageLinksOption{WithPageLinksBaseURL("https://api.example.com/")},
+			uri:  "/users?page=2&size=20",
+			wantHeader: `<https://api.example.com/users?page=1&size=20>; rel="first", ` +
+				`<https://api.example.com/users?page=1&size=20>; rel="prev", ` +
+				`<https://api.example.com/users?page=3&size=20>; rel="next", ` +
+				`<https://api.example.com/users?page=3&size=20>; rel="last"`,
+			wantLinks: &PageLinks{
+				First: "https://api.example.com/users?page=1&size=20",
+				Prev:  "https://api.example.com/users?page=1&size=20",
+				Next:  "https://api.example.com/users?page=3&size=20",
+				Last:  "https://api.example.com/users?page=3&size=20",
+			},
+		},
+		{
+			name: "last-page-header-only",
+			opts: []PageLinksOption{WithPageLinksBody(false)},
+			uri:  "/users?page=3&size=20",
+			wantHeader: `</users?page=1&size=20>; rel="first", ` +
+				`</users?page=2&size=20>; rel="prev", ` +
+				`</users?page=3&size=20>; rel="last"`,
+		},
+		{
+			name: "cursor-first-page",
+			uri:  "/events?size=10",
+			wantHeader: `</events?size=10>; rel="first", ` +
+				`</events?size=10&cursor=` + next + `>; rel="next"`,
+			wantLinks: &PageLinks{
+				First: "/events?size=10",
+				Next:  "/events?size=10&cursor=" + next,
+			},
+		},
+		{
+			name:       "cursor-last-page-body-only",
+			opts:       []PageLinksOption{WithPageLinksHeader(false)},
+			uri:        "/events?size=10&cursor=" + next,
+			wantHeader: "",
+			wantLinks: &PageLinks{
+				First: "/events?size=10",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+
+			w := httptest.NewRecorder()
+			newLinksServer(tt.opts...).ServeHTTP(w, req)
+
+			var resp TypedResponse[struct {
+				Links *PageLinks `json:"links"`
+			}]
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantHeader, w.Header().Get("Link"))
+			assert.Equal(t, tt.wantLinks, resp.Data.Links)
+		})
+	}
+}
+
+func TestCursorPageLinksRaw(t *testing.T) {
+	t.Parallel()
+
+	r := gin.New()
+	r.Use(PageLinksMiddleware(), func(ctx *gin.Context) {
+		ctx.Header("Link", `</style.css>; rel="preload"`)
+	})
+
+	r.GET("/string", func(ctx *gin.Context) {
+		next := "b"
+		if ctx.Query("last") != "" {
+			next = ""
+		}
+
+		TypedCursorPageSuccess(ctx, 2, 1, "a", next, []int{1})
+	})
+	r.GET("/int", func(ctx *gin.Context) {
+		next := 2
+		if ctx.Query("last") != "" {
+			next = 0
+		}
+
+		TypedCursorPageSuccess(ctx, 2, 1, 1, next, []int{1})
+	})
+	r.GET("/struct", func(ctx *gin.Context) {
+		next := testCursor{ID: 2}
+		if ctx.Query("last") != "" {
+			next = testCursor{}
+		}
+
+		TypedCursorPageSuccess(ctx, 2, 1, testCursor{ID: 1}, next, []int{1})
+	})
+
+	tests := []struct {
+		name     string
+		uri      string
+		wantNext string
+	}{
+		{name: "string", uri: "/string", wantNext: "/string?cursor=b"},
+		{name: "string-last", uri: "/string?last=1"},
+		{name: "int", uri: "/int", wantNext: "/int?cursor=2"},
+		{name: "int-last", uri: "/int?last=1"},
+		{name: "struct-without-codec", uri: "/struct"},
+		{name: "struct-last", uri: "/struct?last=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var resp TypedResponse[struct {
+				Links *PageLinks `json:"links"`
+			}]
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+			if assert.NotNil(t, resp.Data.Links) {
+				assert.Equal(t, tt.wantNext, resp.Data.Links.Next)
+				assert.NotEqual(t, resp.Data.Links.First, resp.Data.Links.Next)
+			}
+
+			// The Link header set before is kept
+			assert.Len(t, w.Header().Values("Link"), 2)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/litsea/gin-api/i18n"
+	"github.com/litsea/gin-api/testdata"
+)
+
+func TestLocalize(t *testing.T) {
+	t.Parallel()
+
+	i18n.AssertTranslations(t,
+		i18n.WithCheckSources(
+			i18n.Source{FS: Localize, Dir: "./localize/"},
+			i18n.Source{FS: testdata.Localize, Dir: "./localize/"},
+		),
+		i18n.WithCheckLanguages(language.English),
+		i18n.WithCheckMsgIDs("validation-enum", "validation-test_range", "labelPassword"),
+	)
+}
+
+func TestLocalizeBuiltin(t *testing.T) {
+	t.Parallel()
+
+	r, err := i18n.Check(i18n.WithCheckSources(i18n.Source{FS: Localize, Dir: "./localize/"}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	lngs := make([]language.Tag, 0, len(r.Languages))
+
+	for _, lr := range r.Languages {
+		lngs = append(lngs, lr.Language)
+
+		// Error codes registered by the tests are translated in testdata only
+		lr.Missing = slices.DeleteFunc(lr.Missing, func(id string) bool {
+			return id == errCustom503.Message || id == errCustomLimit.Message
+		})
+
+		assert.False(t, lr.HasIssues(), lr.String())
+	}
+
+	assert.ElementsMatch(t, []language.Tag{
+		language.German, language.English, language.Spanish,
+		language.French, language.Japanese, language.Chinese,
+	}, lngs)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
@@ -29,7 +30,9 @@ func newRequestBody(maxSize int, recordBody bool) *requestBody {
 	}
 }
 
-func (rb *requestBody) read(ctx *gin.Context) error {
+// read the body is redacted by the config, bodies of the content types which are not logged
+// are summarized without reading.
+func (rb *requestBody) read(ctx *gin.Context, cfg *Config) error {
 	if rb.body == nil {
 		return nil
 	}
@@ -38,6 +41,12 @@ func (rb *requestBody) read(ctx *gin.Context) error {
 		return nil
 	}
 
+	contentType := strings.ToLower(ctx.ContentType())
+	if contentType != "" && !cfg.logBody(contentType) {
+		rb.summarize(contentType, ctx.Request.ContentLength)
+		return nil
+	}
+
 	var buf bytes.Buffer
 	tee := io.TeeReader(ctx.Request.Body, &buf)
 	body, err := io.ReadAll(tee)
@@ -46,34 +55,135 @@ func (rb *requestBody) read(ctx *gin.Context) error {
 	}
 
 	ctx.Request.Body = io.NopCloser(&buf)
-	rb.truncateUTF8(body)
+
+	if contentType == "" && !utf8.Valid(body) {
+		rb.summarize(contentType, int64(len(body)))
+		return nil
+	}
+
+	rb.truncateUTF8(cfg.redactBody(contentType, body))
 
 	return err
 }
 
+func (rb *requestBody) summarize(contentType string, size int64) {
+	s := summarizeBody(contentType, size)
+	rb.bytes = len(s)
+	rb.body = bytes.NewBufferString(s)
+}
+
 func (rb *requestBody) truncateUTF8(b []byte) {
 	if len(b) == 0 {
 		return
 	}
 
-	if len(b) <= rb.maxSize {
-		rb.bytes = len(b)
-		rb.body = bytes.NewBuffer(b)
-		return
+	b = truncateUTF8(b, rb.maxSize)
+	rb.bytes = len(b)
+	rb.body = bytes.NewBuffer(b)
+}
+
+// truncateUTF8 at most maxSize bytes without splitting a multi-byte character.
+func truncateUTF8(b []byte, maxSize int) []byte {
+	if len(b) <= maxSize {
+		return b
 	}
 
 	i := 0
-	for i < rb.maxSize {
-		if i+utf8.RuneLen(rune(b[i])) > rb.maxSize {
+	for i < maxSize {
+		if i+utf8.RuneLen(rune(b[i])) > maxSize {
 			break
 		}
 		_, size := utf8.DecodeRune(b[i:])
-		if i+size > rb.maxSize {
+		if i+size > maxSize {
 			break
 		}
 		i += size
 	}
 
-	rb.bytes = i
-	rb.body = bytes.NewBuffer(b[:i])
+	return b[:i]
+}
+
+// responseBodyWriter captures the body of the error responses (status >= 400),
+// the body of other responses is not buffered.
+type responseBodyWriter struct {
+	gin.ResponseWriter
+
+	cfg     *Config
+	body    *bytes.Buffer
+	maxSize int
+}
+
+func newResponseBodyWriter(w gin.ResponseWriter, cfg *Config, maxSize int) *responseBodyWriter {
+	return &responseBodyWriter{
+		ResponseWriter: w,
+		cfg:            cfg,
+		maxSize:        maxSize,
+	}
+}
+
+func (w *responseBodyWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.capture(b[:n])
+
+	return n, err
+}
+
+func (w *responseBodyWriter) WriteString(s string) (int, error) {
+	n, err := w.ResponseWriter.WriteString(s)
+	if w.capturing() {
+		w.capture([]byte(s[:n]))
+	}
+
+	return n, err
+}
+
+func (w *responseBodyWriter) capturing() bool {
+	return w.Status() >= http.StatusBadRequest
+}
+
+// capture up to maxSize bytes and the following partial character to truncate it.
+func (w *responseBodyWriter) capture(b []byte) {
+	if !w.capturing() {
+		return
+	}
+
+	if w.body == nil {
+		w.body = new(bytes.Buffer)
+	}
+
+	if room := w.maxSize + utf8.UTFMax - w.body.Len(); room < len(b) {
+		b = b[:max(room, 0)]
+	}
+
+	w.body.Write(b)
+}
+
+// String the captured body, redacted and truncated.
+func (w *responseBodyWriter) String() string {
+	if w.body == nil {
+		return ""
+	}
+
+	return w.cfg.responseBody(w.Header(), w.body.Bytes(), w.Size(), w.maxSize)
+}
+
+// responseBody the body of the content type which is not logged is summarized, otherwise
+// redacted and truncated, the body of maxSize+ bytes is not valid JSON and masked by field rules.
+func (cfg *Config) responseBody(h http.Header, b []byte, size, maxSize int) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	contentType := h.Get("Content-Type")
+	if ct, _, found := strings.Cut(contentType, ";"); found {
+		contentType = ct
+	}
+
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+
+	if contentType != "" && !cfg.logBody(contentType) {
+		return summarizeBody(contentType, int64(size))
+	}
+
+	return string(truncateUTF8(cfg.redactBody(contentType, b), maxSize))
 }
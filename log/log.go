@@ -3,9 +3,10 @@ package log
 import (
 	"context"
 	"log/slog"
+	"regexp"
 	"runtime"
 	"runtime/debug"
-	"strings"
+	"slices"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,6 +17,10 @@ var (
 
 	RequestBodyMaxSize = 64 * 1024 // 64KB
 
+	ResponseBodyMaxSize = 64 * 1024 // 64KB
+
+	// HiddenRequestHeaders default hidden request headers, read when logging,
+	// see WithHiddenRequestHeaders() and WithRequestHeaderDeny()
 	HiddenRequestHeaders = map[string]struct{}{
 		"authorization": {},
 		"cookie":        {},
@@ -49,10 +54,22 @@ type Logger interface {
 type Config struct {
 	withUserAgent      bool
 	withRequestBody    bool
+	withResponseBody   bool
 	withRequestHeader  bool
 	withStackTrace     bool
 	requestIDHeaderKey string
 	extraAttrs         map[string]any
+	accessLog          bool
+	accessLogSkipPaths []string
+	withTraceContext   bool
+	requestHeaderAllow map[string]struct{}
+	requestHeaderDeny  map[string]struct{}
+	hiddenHeaders      map[string]struct{}
+	redactFields       [][]string
+	keepUnparsedBody   bool
+	redactPatterns     []*regexp.Regexp
+	redactMask         string
+	bodyContentTypes   []string
 }
 
 type DefaultLogger struct {
@@ -74,10 +91,13 @@ func New(sl *slog.Logger, opts ...Option) *DefaultLogger {
 	cfg := &Config{
 		withUserAgent:      false,
 		withRequestBody:    false,
+		withResponseBody:   false,
 		withRequestHeader:  false,
 		withStackTrace:     false,
 		requestIDHeaderKey: defaultRequestIDHeaderKey,
 		extraAttrs:         defaultLogExtraAttrs,
+		redactMask:         defaultRedactMask,
+		bodyContentTypes:   defaultBodyContentTypes,
 	}
 
 	for _, opt := range opts {
@@ -162,14 +182,6 @@ func (l *DefaultLogger) logRequest(ctx *gin.Context, lv slog.Level, msg string,
 	host := ctx.Request.Host
 	path := ctx.Request.URL.Path
 
-	var status int
-	st, ok := args["status"]
-	if ok {
-		if sts, ok := st.(int); ok {
-			status = sts
-		}
-	}
-
 	params := map[string]string{}
 	for _, p := range ctx.Params {
 		params[p.Key] = p.Value
@@ -201,9 +213,17 @@ func (l *DefaultLogger) logRequest(ctx *gin.Context, lv slog.Level, msg string,
 		kv := make([]any, 0, len(ctx.Request.Header))
 
 		for k, v := range ctx.Request.Header {
-			if _, found := HiddenRequestHeaders[strings.ToLower(k)]; found {
+			if l.cfg.hideHeader(k) {
 				continue
 			}
+
+			if len(l.cfg.redactPatterns) > 0 {
+				v = slices.Clone(v)
+				for i := range v {
+					v[i] = l.cfg.redactValue(v[i])
+				}
+			}
+
 			kv = append(kv, slog.Any(k, v))
 		}
 
@@ -224,17 +244,33 @@ func (l *DefaultLogger) logRequest(ctx *gin.Context, lv slog.Level, msg string,
 		},
 	}
 
-	if status > 0 {
-		responseAttributes := []slog.Attr{
-			slog.Int("status", status),
+	var responseAttributes []slog.Attr
+
+	// status/size/latency/code of the access record, only the status of other records
+	if ar, ok := args[responseAttrKey].(accessResponse); ok {
+		responseAttributes = ar.attrs()
+	} else if st, ok := args["status"].(int); ok && st > 0 {
+		responseAttributes = append(responseAttributes, slog.Int("status", st))
+	}
+
+	// response body of error records
+	if l.cfg.withResponseBody && lv >= slog.LevelError {
+		if body := GetResponseBody(ctx); body != "" {
+			responseAttributes = append(responseAttributes, slog.String("body", body))
 		}
+	}
 
+	if len(responseAttributes) > 0 {
 		attributes = append(attributes, slog.Attr{
 			Key:   "response",
 			Value: slog.GroupValue(responseAttributes...),
 		})
 	}
 
+	if tc, ok := GetTraceContext(ctx); ok {
+		attributes = append(attributes, slog.String(TraceIDKey, tc.TraceID), slog.String(SpanIDKey, tc.SpanID))
+	}
+
 	// custom context values
 	for k, v := range l.cfg.extraAttrs {
 		attributes = append(attributes, slog.Any(k, v))
@@ -244,11 +280,20 @@ func (l *DefaultLogger) logRequest(ctx *gin.Context, lv slog.Level, msg string,
 		if k == "status" {
 			continue
 		}
+
+		if _, ok := v.(accessResponse); ok && k == responseAttrKey {
+			continue
+		}
+
 		attributes = append(attributes, slog.Any(k, v))
 	}
 
 	if l.cfg.withStackTrace {
-		trace := debug.Stack()
+		trace, ok := GetStackTraceFromContext(ctx)
+		if !ok {
+			trace = debug.Stack()
+		}
+
 		attributes = append(attributes, slog.Any("stacktrace", string(trace)))
 	}
 
@@ -3,6 +3,10 @@ package log
 import (
 	"bytes"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -12,18 +16,26 @@ const (
 	customLoggerContextKey    = "litsea.gin-api.log"
 	requestIDCtxKey           = "litsea.gin-api.log.request-id"
 	requestBodyCtxKey         = "litsea.gin-api.log.request-body"
+	responseBodyCtxKey        = "litsea.gin-api.log.response-body"
+	errorCodeCtxKey           = "litsea.gin-api.log.error-code"
+	stackTraceCtxKey          = "litsea.gin-api.log.stack-trace"
 	defaultRequestIDHeaderKey = "X-Request-ID"
+
+	// responseAttrKey attrs key of the response group of the access record
+	responseAttrKey = "response"
 )
 
 func Middleware(l Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		start := time.Now()
+
 		if l != nil {
 			SetLoggerToContext(c, l)
 		}
 
 		if l.Config().withRequestBody {
 			rb := newRequestBody(RequestBodyMaxSize, l.Config().withRequestBody)
-			err := rb.read(c)
+			err := rb.read(c, l.Config())
 			if err != nil {
 				l.Error("log.Middleware: read requestBody failed", "err", fmt.Errorf("%w", err))
 			} else {
@@ -31,6 +43,17 @@ func Middleware(l Logger) gin.HandlerFunc {
 			}
 		}
 
+		if l.Config().withResponseBody {
+			w := newResponseBodyWriter(c.Writer, l.Config(), ResponseBodyMaxSize)
+			c.Writer = w
+			c.Set(responseBodyCtxKey, w)
+		}
+
+		// The trace context set before, e.g. by the otel middleware, is kept
+		if _, ok := GetTraceContext(c); !ok && l.Config().withTraceContext {
+			SetTraceContextToContext(c, newTraceContext(c.GetHeader(TraceParentHeader), c.GetHeader(TraceStateHeader)))
+		}
+
 		if l.Config().requestIDHeaderKey != "" {
 			requestID := c.GetHeader(l.Config().requestIDHeaderKey)
 			if requestID == "" {
@@ -40,10 +63,80 @@ func Middleware(l Logger) gin.HandlerFunc {
 			c.Set(requestIDCtxKey, requestID)
 		}
 
+		if l.Config().accessLog && !slices.ContainsFunc(l.Config().accessLogSkipPaths, func(p string) bool {
+			return p == c.Request.URL.Path || p == c.FullPath()
+		}) {
+			// The access record is not lost on panic
+			defer func() {
+				err := recover()
+				accessLog(c, l, time.Since(start), err != nil)
+
+				if err != nil {
+					panic(err)
+				}
+			}()
+		}
+
 		c.Next()
 	}
 }
 
+// accessResponse attributes of the response group of the access record.
+type accessResponse struct {
+	status  int
+	size    int
+	latency time.Duration
+	code    int
+	hasCode bool
+}
+
+func (r accessResponse) attrs() []slog.Attr {
+	attrs := []slog.Attr{
+		slog.Int("status", r.status),
+		slog.Int("size", r.size),
+		slog.Duration("latency", r.latency),
+	}
+
+	if r.hasCode {
+		attrs = append(attrs, slog.Int("code", r.code))
+	}
+
+	return attrs
+}
+
+// LogValue the response group for custom Logger implementations.
+func (r accessResponse) LogValue() slog.Value {
+	return slog.GroupValue(r.attrs()...)
+}
+
+// accessLog the panicking request without a written response is logged with status 500.
+func accessLog(c *gin.Context, l Logger, latency time.Duration, panicking bool) {
+	r := accessResponse{
+		status:  c.Writer.Status(),
+		size:    max(c.Writer.Size(), 0),
+		latency: latency,
+	}
+
+	if panicking && !c.Writer.Written() {
+		r.status = http.StatusInternalServerError
+	}
+
+	r.code, r.hasCode = GetErrorCodeFromContext(c)
+
+	attrs := map[string]any{
+		responseAttrKey: r,
+	}
+
+	switch {
+	case r.status >= http.StatusInternalServerError:
+		l.ErrorRequest(c, "Access", attrs)
+	case r.status >= http.StatusBadRequest:
+		l.WarnRequest(c, "Access", attrs)
+	default:
+		l.InfoRequest(c, "Access", attrs)
+	}
+}
+
 // GetLoggerFromContext get logger context set by SetLoggerToContext()
 //
 //nolint:ireturn
@@ -93,3 +186,66 @@ func GetRequestBody(ctx *gin.Context) (*bytes.Buffer, int) {
 
 	return bytes.NewBuffer([]byte{}), 0
 }
+
+// ResponseBodyBuffer a response writer buffering the body until the handlers return,
+// e.g. of the timeout middleware, the buffered body is logged instead of the written body.
+type ResponseBodyBuffer interface {
+	gin.ResponseWriter
+
+	// BufferedBody the body which is not written yet, it must not be modified.
+	BufferedBody() []byte
+}
+
+// GetResponseBody the captured body of the error response by WithResponseBody(true).
+func GetResponseBody(ctx *gin.Context) string {
+	v, ok := ctx.Get(responseBodyCtxKey)
+	if !ok {
+		return ""
+	}
+
+	w, ok := v.(*responseBodyWriter)
+	if !ok {
+		return ""
+	}
+
+	if bw, ok := ctx.Writer.(ResponseBodyBuffer); ok && bw.Status() >= http.StatusBadRequest {
+		if b := bw.BufferedBody(); len(b) > 0 {
+			return w.cfg.responseBody(bw.Header(), b, len(b), w.maxSize)
+		}
+	}
+
+	return w.String()
+}
+
+// SetErrorCodeToContext the error code of the response, logged by the access log.
+func SetErrorCodeToContext(ctx *gin.Context, code int) {
+	ctx.Set(errorCodeCtxKey, code)
+}
+
+func GetErrorCodeFromContext(ctx *gin.Context) (int, bool) {
+	v, ok := ctx.Get(errorCodeCtxKey)
+	if !ok {
+		return 0, false
+	}
+
+	code, ok := v.(int)
+
+	return code, ok
+}
+
+// SetStackTraceToContext the stack trace logged by WithStackTrace(true) instead of the stack of the log call,
+// e.g. the stack of a panic recovered in another goroutine.
+func SetStackTraceToContext(ctx *gin.Context, stack []byte) {
+	ctx.Set(stackTraceCtxKey, stack)
+}
+
+func GetStackTraceFromContext(ctx *gin.Context) ([]byte, bool) {
+	v, ok := ctx.Get(stackTraceCtxKey)
+	if !ok {
+		return nil, false
+	}
+
+	stack, ok := v.([]byte)
+
+	return stack, ok
+}
@@ -0,0 +1,198 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(mw ...gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(gin.CustomRecovery(func(ctx *gin.Context, _ any) {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	r.Use(mw...)
+
+	r.GET("/ok", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"code": 0, "msg": "Success"})
+	})
+	r.GET("/403", func(ctx *gin.Context) {
+		SetErrorCodeToContext(ctx, http.StatusForbidden)
+		ctx.JSON(http.StatusForbidden, gin.H{"code": http.StatusForbidden, "msg": "Forbidden"})
+	})
+	r.GET("/503", func(ctx *gin.Context) {
+		SetErrorCodeToContext(ctx, 999)
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"code": 999, "msg": "Service Unavailable"})
+		ErrorRequest(ctx, "API error", map[string]any{"status": http.StatusServiceUnavailable})
+	})
+	r.GET("/large", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"msg": strings.Repeat("x", ResponseBodyMaxSize)})
+		ErrorRequest(ctx, "API error", map[string]any{"status": http.StatusInternalServerError})
+	})
+	r.GET("/panic", func(_ *gin.Context) {
+		panic("boom")
+	})
+	r.GET("/attrs", func(ctx *gin.Context) {
+		InfoRequest(ctx, "attrs", map[string]any{"status": http.StatusOK, "size": "big", "code": "abc"})
+		ctx.Status(http.StatusNoContent)
+	})
+	r.GET("/healthz", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	r.GET("/users/:id", func(ctx *gin.Context) { ctx.String(http.StatusOK, ctx.Param("id")) })
+
+	return r
+}
+
+func TestAccessLog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		uri  string
+		want []string
+	}{
+		{
+			name: "ok",
+			uri:  "/ok",
+			want: []string{"level=INFO msg=Access", "request.route=/ok", "response.status=200 response.size=26 response.latency="},
+		},
+		{
+			name: "4xx",
+			uri:  "/403",
+			want: []string{"level=WARN msg=Access", "response.status=403 response.size=", "response.code=403"},
+		},
+		{
+			name: "5xx",
+			uri:  "/503",
+			want: []string{"level=ERROR msg=Access", "response.status=503", "response.code=999"},
+		},
+		{
+			name: "panic",
+			uri:  "/panic",
+			want: []string{"level=ERROR msg=Access", "response.status=500 response.size=0"},
+		},
+		{name: "skip-path", uri: "/healthz"},
+		{name: "skip-route", uri: "/users/1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := new(strings.Builder)
+			l := New(
+				slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{Level: slog.LevelInfo})),
+				WithAccessLog(true),
+				WithAccessLogSkipPaths("/healthz", "/users/:id"),
+			)
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+			newTestServer(Middleware(l)).ServeHTTP(httptest.NewRecorder(), req)
+
+			var access []string
+			for _, line := range strings.Split(buffer.String(), "\n") {
+				if strings.Contains(line, "msg=Access") {
+					access = append(access, line)
+				}
+			}
+
+			if len(tt.want) == 0 {
+				assert.Empty(t, access)
+				return
+			}
+
+			if assert.Len(t, access, 1) {
+				for _, w := range tt.want {
+					assert.Contains(t, access[0], w)
+				}
+			}
+		})
+	}
+}
+
+func TestResponseAttrs(t *testing.T) {
+	t.Parallel()
+
+	buffer := new(strings.Builder)
+	l := New(slog.New(slog.NewJSONHandler(buffer, nil)))
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/attrs", http.NoBody)
+	newTestServer(Middleware(l)).ServeHTTP(httptest.NewRecorder(), req)
+
+	var record map[string]any
+	if assert.NoError(t, json.Unmarshal([]byte(buffer.String()), &record)) {
+		// Only the status is reserved for the records except the access record
+		assert.Equal(t, map[string]any{"status": float64(http.StatusOK)}, record["response"])
+		assert.Equal(t, "big", record["size"])
+		assert.Equal(t, "abc", record["code"])
+	}
+}
+
+func TestResponseBodyLog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		uri  string
+		want map[string]string // message: response body
+	}{
+		{
+			name: "error",
+			uri:  "/503",
+			want: map[string]string{
+				"API error": `{"code":999,"msg":"[REDACTED]"}`,
+				"Access":    `{"code":999,"msg":"[REDACTED]"}`,
+			},
+		},
+		{
+			name: "truncated",
+			uri:  "/large",
+			want: map[string]string{
+				"API error": "[REDACTED]",
+				"Access":    "[REDACTED]",
+			},
+		},
+		{name: "warn", uri: "/403", want: map[string]string{"Access": ""}},
+		{name: "success", uri: "/users/1", want: map[string]string{"Access": ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := new(strings.Builder)
+			l := New(
+				slog.New(slog.NewJSONHandler(buffer, nil)),
+				WithAccessLog(true),
+				WithResponseBody(true),
+				WithRedactFields("$.msg"),
+			)
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+			newTestServer(Middleware(l)).ServeHTTP(httptest.NewRecorder(), req)
+
+			got := map[string]string{}
+
+			for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+				var record struct {
+					Msg      string `json:"msg"`
+					Response struct {
+						Body string `json:"body"`
+					} `json:"response"`
+				}
+
+				if assert.NoError(t, json.Unmarshal([]byte(line), &record)) {
+					got[record.Msg] = record.Response.Body
+				}
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package log
 
+import (
+	"regexp"
+	"strings"
+)
+
 type Option func(cfg *Config)
 
 func WithRequestIDHeaderKey(v string) Option {
@@ -26,6 +31,24 @@ func WithRequestBody(v bool) Option {
 	}
 }
 
+// WithResponseBody log the body of the error responses (status >= 400) in the response group
+// of error records, up to ResponseBodyMaxSize and redacted as the request body.
+// Middleware() wraps the response writer of each request,
+// the buffer is only allocated for error responses.
+func WithResponseBody(v bool) Option {
+	return func(cfg *Config) {
+		cfg.withResponseBody = v
+	}
+}
+
+// WithTraceContext parse the W3C traceparent and tracestate headers by Middleware(),
+// a new trace is started if they are missing, see GetTraceContext().
+func WithTraceContext(v bool) Option {
+	return func(cfg *Config) {
+		cfg.withTraceContext = v
+	}
+}
+
 func WithStackTrace(v bool) Option {
 	return func(cfg *Config) {
 		cfg.withStackTrace = v
@@ -39,3 +62,104 @@ func WithExtraAttrs(attrs map[string]any) Option {
 		}
 	}
 }
+
+// WithAccessLog log each request by Middleware(), the level is by the response status,
+// error for 5xx, warn for 4xx, otherwise info.
+func WithAccessLog(v bool) Option {
+	return func(cfg *Config) {
+		cfg.accessLog = v
+	}
+}
+
+// WithAccessLogSkipPaths request paths or routes without access log, e.g. "/healthz".
+func WithAccessLogSkipPaths(paths ...string) Option {
+	return func(cfg *Config) {
+		cfg.accessLogSkipPaths = append(cfg.accessLogSkipPaths, paths...)
+	}
+}
+
+// WithRequestHeaderAllow only the request headers are logged by WithRequestHeader(true).
+func WithRequestHeaderAllow(names ...string) Option {
+	return func(cfg *Config) {
+		if cfg.requestHeaderAllow == nil {
+			cfg.requestHeaderAllow = map[string]struct{}{}
+		}
+
+		for _, n := range names {
+			cfg.requestHeaderAllow[strings.ToLower(n)] = struct{}{}
+		}
+	}
+}
+
+// WithHiddenRequestHeaders overrides HiddenRequestHeaders for the logger, none are hidden without names.
+func WithHiddenRequestHeaders(names ...string) Option {
+	return func(cfg *Config) {
+		cfg.hiddenHeaders = map[string]struct{}{}
+
+		for _, n := range names {
+			cfg.hiddenHeaders[strings.ToLower(n)] = struct{}{}
+		}
+	}
+}
+
+// WithRequestHeaderDeny request headers hidden in addition to HiddenRequestHeaders.
+func WithRequestHeaderDeny(names ...string) Option {
+	return func(cfg *Config) {
+		if cfg.requestHeaderDeny == nil {
+			cfg.requestHeaderDeny = map[string]struct{}{}
+		}
+
+		for _, n := range names {
+			cfg.requestHeaderDeny[strings.ToLower(n)] = struct{}{}
+		}
+	}
+}
+
+// WithRedactFields masks the fields of JSON and form request bodies by case-insensitive path,
+// e.g. "$.password", "card.*", "items[*].token", "*" matches any key or array index.
+// Bodies of any content type are parsed as JSON except forms. Fail-closed: the body which is not
+// valid JSON, e.g. XML, plain text or truncated JSON, is replaced by the mask entirely,
+// see WithRedactUnparsedBody().
+func WithRedactFields(paths ...string) Option {
+	return func(cfg *Config) {
+		for _, p := range paths {
+			cfg.redactFields = append(cfg.redactFields, parseRedactPath(p))
+		}
+	}
+}
+
+// WithRedactUnparsedBody whether the body which is not parsed by WithRedactFields() is replaced by the mask,
+// default true, otherwise it is logged with the matches of WithRedactPatterns() masked.
+func WithRedactUnparsedBody(v bool) Option {
+	return func(cfg *Config) {
+		cfg.keepUnparsedBody = !v
+	}
+}
+
+// WithRedactPatterns masks the matches in the request body and header values,
+// e.g. regexp.MustCompile(`\b\d{13,19}\b`) for card numbers.
+func WithRedactPatterns(res ...*regexp.Regexp) Option {
+	return func(cfg *Config) {
+		cfg.redactPatterns = append(cfg.redactPatterns, res...)
+	}
+}
+
+// WithRedactMask replacement of the masked values, default "[REDACTED]".
+func WithRedactMask(s string) Option {
+	return func(cfg *Config) {
+		if s != "" {
+			cfg.redactMask = s
+		}
+	}
+}
+
+// WithRequestBodyContentTypes content types of the logged request bodies, e.g. "application/json", "text/*",
+// other bodies are summarized with the content type and size, e.g. multipart uploads.
+// Default JSON, XML, form and text.
+func WithRequestBodyContentTypes(types ...string) Option {
+	return func(cfg *Config) {
+		if len(types) > 0 {
+			cfg.bodyContentTypes = types
+		}
+	}
+}
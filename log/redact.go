@@ -0,0 +1,225 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const defaultRedactMask = "[REDACTED]"
+
+// Request bodies of other content types are summarized instead of logged, see WithRequestBodyContentTypes().
+var defaultBodyContentTypes = []string{
+	"application/json",
+	"application/*+json",
+	"application/x-www-form-urlencoded",
+	"application/xml",
+	"application/*+xml",
+	"text/*",
+}
+
+// parseRedactPath JSON path or form field name to the path segments,
+// e.g. "$.card.number", "card.*", "items[*].token", "card[number]".
+func parseRedactPath(s string) []string {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "$")
+	s = strings.NewReplacer("[", ".", "]", "").Replace(s)
+
+	return strings.Split(strings.Trim(s, "."), ".")
+}
+
+// matchRedactPath "*" matches any key or array index of the segment, keys are case-insensitive.
+func matchRedactPath(rule, p []string) bool {
+	if len(rule) != len(p) {
+		return false
+	}
+
+	for i, seg := range rule {
+		if seg != "*" && !strings.EqualFold(seg, p[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cfg *Config) mask() string {
+	if cfg.redactMask == "" {
+		return defaultRedactMask
+	}
+
+	return cfg.redactMask
+}
+
+// hideHeader hidden and denied headers are hidden, only the allowed headers are logged if any.
+func (cfg *Config) hideHeader(k string) bool {
+	k = strings.ToLower(k)
+
+	hidden := cfg.hiddenHeaders
+	if hidden == nil {
+		hidden = HiddenRequestHeaders
+	}
+
+	if _, found := hidden[k]; found {
+		return true
+	}
+
+	if _, found := cfg.requestHeaderDeny[k]; found {
+		return true
+	}
+
+	if len(cfg.requestHeaderAllow) == 0 {
+		return false
+	}
+
+	_, found := cfg.requestHeaderAllow[k]
+
+	return !found
+}
+
+// redactValue masks the matches of the redaction patterns.
+func (cfg *Config) redactValue(s string) string {
+	for _, re := range cfg.redactPatterns {
+		s = re.ReplaceAllLiteralString(s, cfg.mask())
+	}
+
+	return s
+}
+
+func (cfg *Config) redactField(p []string) bool {
+	for _, rule := range cfg.redactFields {
+		if matchRedactPath(rule, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// logBody whether the body of the content type (without parameters) is logged.
+func (cfg *Config) logBody(contentType string) bool {
+	types := cfg.bodyContentTypes
+	if len(types) == 0 {
+		types = defaultBodyContentTypes
+	}
+
+	for _, t := range types {
+		if ok, _ := path.Match(t, contentType); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactBody masks the fields of form bodies and bodies parsed as JSON of any content type,
+// then the matches of the patterns. With field rules, the body which is not parsed as JSON,
+// e.g. XML, invalid or truncated, is replaced by the mask unless WithRedactUnparsedBody(false).
+func (cfg *Config) redactBody(contentType string, b []byte) []byte {
+	if len(cfg.redactFields) > 0 && len(b) > 0 {
+		if contentType == "application/x-www-form-urlencoded" {
+			b = cfg.redactForm(b)
+		} else {
+			rb, ok := cfg.redactJSON(b)
+			switch {
+			case ok:
+				b = rb
+			case !cfg.keepUnparsedBody:
+				return []byte(cfg.mask())
+			}
+		}
+	}
+
+	if len(cfg.redactPatterns) == 0 {
+		return b
+	}
+
+	return []byte(cfg.redactValue(string(b)))
+}
+
+// redactJSON false if the body is not a single valid JSON value.
+func (cfg *Config) redactJSON(b []byte) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, false
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, false
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(cfg.redactJSONValue(v, nil)); err != nil {
+		return nil, false
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), true
+}
+
+func (cfg *Config) redactJSONValue(v any, p []string) any {
+	switch vv := v.(type) {
+	case map[string]any:
+		for k, child := range vv {
+			vv[k] = cfg.redactJSONChild(child, append(p, k))
+		}
+	case []any:
+		for i, child := range vv {
+			vv[i] = cfg.redactJSONChild(child, append(p, strconv.Itoa(i)))
+		}
+	}
+
+	return v
+}
+
+func (cfg *Config) redactJSONChild(v any, p []string) any {
+	if cfg.redactField(p) {
+		return cfg.mask()
+	}
+
+	return cfg.redactJSONValue(v, p)
+}
+
+// redactForm masks the values in place to keep the order of the fields.
+func (cfg *Config) redactForm(b []byte) []byte {
+	pairs := strings.Split(string(b), "&")
+
+	for i, pair := range pairs {
+		k, _, _ := strings.Cut(pair, "=")
+
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			key = k
+		}
+
+		if cfg.redactField(parseRedactPath(key)) {
+			pairs[i] = k + "=" + cfg.mask()
+		}
+	}
+
+	return []byte(strings.Join(pairs, "&"))
+}
+
+// summarizeBody logged instead of the body of the content type which is not logged.
+func summarizeBody(contentType string, size int64) string {
+	if contentType == "" {
+		contentType = "binary"
+	}
+
+	if size < 0 {
+		return fmt.Sprintf("[omitted %s body]", contentType)
+	}
+
+	return fmt.Sprintf("[omitted %s body, %d bytes]", contentType, size)
+}
@@ -0,0 +1,243 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestLogRedaction(t *testing.T) {
+	t.Parallel()
+
+	fields := []string{"$.password", "card.*", "items[*].token"}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		header      map[string]string
+		fields      []string
+		opts        []Option
+		wantBody    string
+		wantHeader  map[string]any
+	}{
+		{
+			name:        "json",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"user":"foo","password":"secret","card":{"number":"4111111111111111","cvv":"123"},"items":[{"token":"t1"}]}`,
+			fields:      fields,
+			wantBody:    `{"card":{"cvv":"[REDACTED]","number":"[REDACTED]"},"items":[{"token":"[REDACTED]"}],"password":"[REDACTED]","user":"foo"}`,
+		},
+		{
+			name:        "json-case-insensitive",
+			contentType: "application/json",
+			body:        `{"Password":"secret","CARD":{"Number":"4111"}}`,
+			fields:      fields,
+			wantBody:    `{"CARD":{"Number":"[REDACTED]"},"Password":"[REDACTED]"}`,
+		},
+		{
+			name:        "json-text-plain",
+			contentType: "text/plain",
+			body:        `{"user":"foo","password":"secret"}`,
+			fields:      fields,
+			wantBody:    `{"password":"[REDACTED]","user":"foo"}`,
+		},
+		{
+			name:     "json-without-content-type",
+			body:     `{"user":"foo","password":"secret"}`,
+			fields:   fields,
+			wantBody: `{"password":"[REDACTED]","user":"foo"}`,
+		},
+		{
+			name:        "json-invalid",
+			contentType: "application/json",
+			body:        `{"password":"secret", note: 4111 1111 1111 1111`,
+			fields:      fields,
+			wantBody:    `[REDACTED]`,
+		},
+		{
+			name:        "json-trailing-data",
+			contentType: "application/json",
+			body:        `{"user":"foo"} password=secret`,
+			fields:      fields,
+			wantBody:    `[REDACTED]`,
+		},
+		{
+			name:        "xml-with-fields",
+			contentType: "application/xml",
+			body:        "<user><password>secret</password></user>",
+			fields:      fields,
+			wantBody:    `[REDACTED]`,
+		},
+		{
+			name:        "xml-keep-unparsed",
+			contentType: "application/xml",
+			body:        "<user><password>secret</password><card>4111 1111 1111 1111</card></user>",
+			fields:      fields,
+			opts:        []Option{WithRedactUnparsedBody(false)},
+			wantBody:    "<user><password>secret</password><card>[REDACTED]</card></user>",
+		},
+		{
+			name:        "text-with-fields",
+			contentType: "text/plain",
+			body:        "password=secret",
+			fields:      fields,
+			wantBody:    `[REDACTED]`,
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "user=foo&PASSWORD=secret&card%5Bnumber%5D=4111",
+			fields:      fields,
+			wantBody:    "user=foo&PASSWORD=[REDACTED]&card%5Bnumber%5D=[REDACTED]",
+		},
+		{
+			name:        "pattern",
+			contentType: "text/plain",
+			body:        "card 4111 1111 1111 1111 paid",
+			wantBody:    "card [REDACTED] paid",
+		},
+		{
+			name:        "multipart",
+			contentType: "multipart/form-data; boundary=x",
+			body:        "--x--",
+			wantBody:    "[omitted multipart/form-data body, 5 bytes]",
+		},
+		{
+			name:     "binary",
+			body:     "\xff\xfe\x00",
+			wantBody: "[omitted binary body, 3 bytes]",
+		},
+		{
+			name:     "headers",
+			header:   map[string]string{"Authorization": "Bearer x", "X-Api-Key": "k", "X-Card": "4111 1111 1111 1111", "X-Other": "v"},
+			wantBody: "",
+			wantHeader: map[string]any{
+				"X-Card": []any{"[REDACTED]"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := new(strings.Builder)
+			l := New(
+				slog.New(slog.NewJSONHandler(buffer, nil)),
+				append([]Option{
+					WithRequestBody(true),
+					WithRequestHeader(true),
+					WithRequestHeaderAllow("Authorization", "X-Api-Key", "X-Card"),
+					WithRequestHeaderDeny("X-Api-Key"),
+					WithRedactFields(tt.fields...),
+					WithRedactPatterns(regexp.MustCompile(`\b\d{4}( ?\d{4}){3}\b`)),
+				}, tt.opts...)...,
+			)
+
+			var received string
+
+			s := newTestServer(Middleware(l))
+			s.POST("/echo", func(ctx *gin.Context) {
+				b, _ := ctx.GetRawData()
+				received = string(b)
+				InfoRequest(ctx, "echo", nil)
+			})
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/echo", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+
+			s.ServeHTTP(httptest.NewRecorder(), req)
+
+			// The handler receives the original body
+			assert.Equal(t, tt.body, received)
+
+			var record struct {
+				Request struct {
+					Header map[string]any `json:"header"`
+					Body   string         `json:"body"`
+				} `json:"request"`
+			}
+
+			if assert.NoError(t, json.Unmarshal([]byte(buffer.String()), &record)) {
+				assert.Equal(t, tt.wantBody, record.Request.Body)
+
+				if tt.wantHeader != nil {
+					assert.Equal(t, tt.wantHeader, record.Request.Header)
+				}
+			}
+		})
+	}
+}
+
+func TestHiddenRequestHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []Option
+		want map[string]any
+	}{
+		{
+			name: "default",
+			want: map[string]any{"X-Other": []any{"v"}},
+		},
+		{
+			name: "override",
+			opts: []Option{WithHiddenRequestHeaders("X-Other")},
+			want: map[string]any{"Authorization": []any{"Bearer x"}},
+		},
+		{
+			name: "override-deny",
+			opts: []Option{WithHiddenRequestHeaders(), WithRequestHeaderDeny("X-Other")},
+			want: map[string]any{"Authorization": []any{"Bearer x"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := new(strings.Builder)
+			l := New(
+				slog.New(slog.NewJSONHandler(buffer, nil)),
+				append([]Option{WithRequestHeader(true)}, tt.opts...)...,
+			)
+
+			s := newTestServer(Middleware(l))
+			s.GET("/headers", func(ctx *gin.Context) {
+				InfoRequest(ctx, "headers", nil)
+			})
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/headers", http.NoBody)
+			req.Header.Set("Authorization", "Bearer x")
+			req.Header.Set("X-Other", "v")
+
+			s.ServeHTTP(httptest.NewRecorder(), req)
+
+			var record struct {
+				Request struct {
+					Header map[string]any `json:"header"`
+				} `json:"request"`
+			}
+
+			if assert.NoError(t, json.Unmarshal([]byte(buffer.String()), &record)) {
+				assert.Equal(t, tt.want, record.Request.Header)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package log
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+
+	traceContextCtxKey = "litsea.gin-api.log.trace-context"
+
+	traceVersion      = "00"
+	traceFlagsSampled = 0x01
+	maxTraceStateLen  = 512
+)
+
+var (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// TraceContext W3C trace context of the request, https://www.w3.org/TR/trace-context/
+type TraceContext struct {
+	TraceID      string // 32 lowercase hex digits
+	ParentSpanID string // Span ID of the caller, empty for a new trace
+	SpanID       string // Span ID of the current request, 16 lowercase hex digits
+	Flags        byte   // Trace flags, 0x01 sampled
+	State        string // Vendor specific tracestate, propagated as is
+}
+
+// Traceparent header value with the current span as the parent.
+func (tc TraceContext) Traceparent() string {
+	return traceVersion + "-" + tc.TraceID + "-" + tc.SpanID + "-" + hex.EncodeToString([]byte{tc.Flags})
+}
+
+func (tc TraceContext) Sampled() bool {
+	return tc.Flags&traceFlagsSampled != 0
+}
+
+// newTraceContext continues the trace of the traceparent header with a child span,
+// starts a new trace if it is missing or invalid.
+func newTraceContext(traceparent, tracestate string) TraceContext {
+	tc := TraceContext{
+		SpanID: newTraceID(8),
+	}
+
+	traceID, parentID, flags, ok := parseTraceparent(traceparent)
+	if !ok {
+		tc.TraceID = newTraceID(16)
+		tc.Flags = traceFlagsSampled
+
+		return tc
+	}
+
+	tc.TraceID = traceID
+	tc.ParentSpanID = parentID
+	tc.Flags = flags
+
+	if len(tracestate) <= maxTraceStateLen {
+		tc.State = strings.TrimSpace(tracestate)
+	}
+
+	return tc
+}
+
+// parseTraceparent version-traceid-parentid-flags, e.g. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01,
+// fields after the flags are allowed by future versions.
+func parseTraceparent(s string) (string, string, byte, bool) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 {
+		return "", "", 0, false
+	}
+
+	version, traceID, parentID, flags := parts[0], parts[1], parts[2], parts[3]
+
+	if !isTraceHex(version, 2) || version == "ff" || (version == traceVersion && len(parts) != 4) {
+		return "", "", 0, false
+	}
+
+	if !isTraceHex(traceID, 32) || !isTraceHex(parentID, 16) || !isTraceHex(flags, 2) {
+		return "", "", 0, false
+	}
+
+	f, _ := strconv.ParseUint(flags, 16, 8)
+
+	return traceID, parentID, byte(f), true
+}
+
+// isTraceHex n lowercase hex digits, not all zero.
+func isTraceHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+
+	zero := true
+
+	for _, c := range s {
+		switch {
+		case c == '0':
+		case '1' <= c && c <= '9', 'a' <= c && c <= 'f':
+			zero = false
+		default:
+			return false
+		}
+	}
+
+	// Version and flags may be zero
+	return !zero || n == 2
+}
+
+func newTraceID(n int) string {
+	b := make([]byte, n)
+
+	for {
+		_, _ = rand.Read(b)
+
+		for _, v := range b {
+			if v != 0 {
+				return hex.EncodeToString(b)
+			}
+		}
+	}
+}
+
+// SetTraceContextToContext the trace context of the current span, e.g. of a tracing middleware,
+// which is logged, responded and injected instead of the one started by Middleware().
+func SetTraceContextToContext(ctx *gin.Context, tc TraceContext) {
+	ctx.Set(traceContextCtxKey, tc)
+}
+
+// GetTraceContext get the trace context set by Middleware() with WithTraceContext(true)
+// or SetTraceContextToContext().
+func GetTraceContext(ctx *gin.Context) (TraceContext, bool) {
+	v, ok := ctx.Get(traceContextCtxKey)
+	if !ok {
+		return TraceContext{}, false
+	}
+
+	tc, ok := v.(TraceContext)
+
+	return tc, ok
+}
+
+func GetTraceID(ctx *gin.Context) string {
+	tc, _ := GetTraceContext(ctx)
+	return tc.TraceID
+}
+
+func GetSpanID(ctx *gin.Context) string {
+	tc, _ := GetTraceContext(ctx)
+	return tc.SpanID
+}
+
+// InjectTraceContext set the traceparent and tracestate headers of an outgoing request,
+// the current span is the parent of the downstream span.
+func InjectTraceContext(ctx *gin.Context, h http.Header) {
+	tc, ok := GetTraceContext(ctx)
+	if !ok {
+		return
+	}
+
+	h.Set(TraceParentHeader, tc.Traceparent())
+
+	if tc.State != "" {
+		h.Set(TraceStateHeader, tc.State)
+	}
+}
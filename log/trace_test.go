@@ -0,0 +1,96 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceContext(t *testing.T) {
+	t.Parallel()
+
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+
+	tests := []struct {
+		name        string
+		traceparent string
+		tracestate  string
+		newTrace    bool
+		flags       string
+	}{
+		{name: "continue", traceparent: "00-" + traceID + "-" + parentID + "-01", tracestate: "vendor=abc", flags: "01"},
+		{name: "not-sampled", traceparent: "00-" + traceID + "-" + parentID + "-00", flags: "00"},
+		{name: "future-version", traceparent: "01-" + traceID + "-" + parentID + "-01-extra", flags: "01"},
+		{name: "missing", newTrace: true, flags: "01"},
+		{name: "invalid-version", traceparent: "ff-" + traceID + "-" + parentID + "-01", newTrace: true, flags: "01"},
+		{name: "zero-trace-id", traceparent: "00-" + strings.Repeat("0", 32) + "-" + parentID + "-01", newTrace: true, flags: "01"},
+		{name: "uppercase", traceparent: "00-" + strings.ToUpper(traceID) + "-" + parentID + "-01", newTrace: true, flags: "01"},
+	}
+
+	traceparent := regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$`)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := new(strings.Builder)
+			l := New(slog.New(slog.NewTextHandler(buffer, nil)), WithTraceContext(true))
+
+			var gotTraceID string
+
+			s := newTestServer(Middleware(l))
+			s.GET("/downstream", func(ctx *gin.Context) {
+				h := http.Header{}
+				InjectTraceContext(ctx, h)
+				gotTraceID = GetTraceID(ctx)
+				ErrorRequest(ctx, "downstream", map[string]any{
+					"err": errors.New(h.Get(TraceParentHeader) + " " + h.Get(TraceStateHeader)),
+				})
+			})
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/downstream", http.NoBody)
+			if tt.traceparent != "" {
+				req.Header.Set(TraceParentHeader, tt.traceparent)
+			}
+			if tt.tracestate != "" {
+				req.Header.Set(TraceStateHeader, tt.tracestate)
+			}
+
+			s.ServeHTTP(httptest.NewRecorder(), req)
+
+			out := buffer.String()
+			m := regexp.MustCompile(`err="(\S+) ?(\S*)"`).FindStringSubmatch(out)
+			if !assert.Len(t, m, 3, out) {
+				return
+			}
+
+			sm := traceparent.FindStringSubmatch(m[1])
+			if !assert.Len(t, sm, 4, m[1]) {
+				return
+			}
+
+			if tt.newTrace {
+				assert.NotEqual(t, traceID, sm[1])
+			} else {
+				assert.Equal(t, traceID, sm[1])
+			}
+
+			assert.NotEqual(t, parentID, sm[2])
+			assert.Equal(t, tt.flags, sm[3])
+			assert.Equal(t, tt.tracestate, m[2])
+			assert.Equal(t, sm[1], gotTraceID)
+			assert.Contains(t, out, "trace_id="+sm[1]+" span_id="+sm[2])
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	api "github.com/litsea/gin-api"
+	"github.com/litsea/gin-api/log"
+	"github.com/litsea/gin-api/ratelimit"
+)
+
+const subsystem = "http"
+
+var (
+	requestLabels = []string{"method", "route", "status", "code"}
+	routeLabels   = []string{"method", "route"}
+)
+
+// Metrics Prometheus collectors of the HTTP requests.
+type Metrics struct {
+	namespace       string
+	registerer      prometheus.Registerer
+	gatherer        prometheus.Gatherer
+	durationBuckets []float64
+	sizeBuckets     []float64
+
+	requests   *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	inFlight   *prometheus.GaugeVec
+	size       *prometheus.HistogramVec
+	panics     *prometheus.CounterVec
+	rateLimits *prometheus.CounterVec
+}
+
+// New creates and registers the collectors, labeled by method, route (ctx.FullPath()),
+// status and errcode code:
+//
+//	http_requests_total, http_request_duration_seconds, http_response_size_bytes,
+//	http_requests_in_flight (method and route only),
+//	http_panics_total and http_rate_limit_rejections_total (method and route only).
+func New(opts ...Option) (*Metrics, error) {
+	m := &Metrics{
+		registerer:      prometheus.DefaultRegisterer,
+		gatherer:        prometheus.DefaultGatherer,
+		durationBuckets: prometheus.DefBuckets,
+		sizeBuckets:     prometheus.ExponentialBuckets(100, 10, 6), // 100B to 10MB
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	m.requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Subsystem: subsystem,
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests.",
+	}, requestLabels)
+
+	m.duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: m.namespace,
+		Subsystem: subsystem,
+		Name:      "request_duration_seconds",
+		Help:      "Duration of HTTP requests in seconds.",
+		Buckets:   m.durationBuckets,
+	}, requestLabels)
+
+	m.inFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: m.namespace,
+		Subsystem: subsystem,
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests being served.",
+	}, routeLabels)
+
+	m.size = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: m.namespace,
+		Subsystem: subsystem,
+		Name:      "response_size_bytes",
+		Help:      "Size of HTTP responses in bytes.",
+		Buckets:   m.sizeBuckets,
+	}, requestLabels)
+
+	m.panics = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Subsystem: subsystem,
+		Name:      "panics_total",
+		Help:      "Total number of panics recovered.",
+	}, routeLabels)
+
+	m.rateLimits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Subsystem: subsystem,
+		Name:      "rate_limit_rejections_total",
+		Help:      "Total number of HTTP requests rejected by the rate limit.",
+	}, routeLabels)
+
+	for _, c := range []prometheus.Collector{m.requests, m.duration, m.inFlight, m.size, m.panics, m.rateLimits} {
+		if err := m.registerer.Register(c); err != nil {
+			return nil, fmt.Errorf("metrics.New: %w", err)
+		}
+	}
+
+	return m, nil
+}
+
+// Middleware records the requests, use it before api.Recovery() to count the panics.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		method := ctx.Request.Method
+		route := ctx.FullPath()
+
+		inFlight := m.inFlight.WithLabelValues(method, route)
+		inFlight.Inc()
+
+		defer inFlight.Dec()
+
+		ctx.Next()
+
+		code := ""
+		if c, ok := log.GetErrorCodeFromContext(ctx); ok {
+			code = strconv.Itoa(c)
+		}
+
+		lvs := []string{method, route, strconv.Itoa(ctx.Writer.Status()), code}
+
+		m.requests.WithLabelValues(lvs...).Inc()
+		m.duration.WithLabelValues(lvs...).Observe(time.Since(start).Seconds())
+		m.size.WithLabelValues(lvs...).Observe(float64(max(ctx.Writer.Size(), 0)))
+
+		if api.GetPanicErrorFromContext(ctx) != nil {
+			m.panics.WithLabelValues(method, route).Inc()
+		}
+
+		if _, ok := ratelimit.GetRejectedFromContext(ctx); ok {
+			m.rateLimits.WithLabelValues(method, route).Inc()
+		}
+	}
+}
+
+// Handler exposes the metrics of the registry.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.gatherer, promhttp.HandlerOpts{})
+}
+
+// RouteRegisterMetrics registers GET /metrics protected by the "token" parameter,
+// the same as api.RouteRegisterPprof().
+func RouteRegisterMetrics(r *gin.Engine, m *Metrics, getTokenFn func() string) {
+	if m == nil || getTokenFn == nil {
+		return
+	}
+
+	r.GET("/metrics", api.HandleDebugToken(getTokenFn), gin.WrapH(m.Handler()))
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/litsea/gin-api"
+	"github.com/litsea/gin-api/errcode"
+	"github.com/litsea/gin-api/ratelimit"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		uri      string
+		requests int
+		labels   []string
+		panics   float64
+		rejected float64
+	}{
+		{name: "success", uri: "/users/1", requests: 1, labels: []string{http.MethodGet, "/users/:id", "200", ""}},
+		{name: "error", uri: "/error", requests: 1, labels: []string{http.MethodGet, "/error", "404", "404"}},
+		{name: "panic", uri: "/panic", requests: 1, labels: []string{http.MethodGet, "/panic", "500", "500"}, panics: 1},
+		{name: "rate-limit", uri: "/limited", requests: 2, labels: []string{http.MethodGet, "/limited", "429", "429"}, rejected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reg := prometheus.NewRegistry()
+			m, err := New(WithRegistry(reg), WithNamespace("test"))
+			require.NoError(t, err)
+
+			r := gin.New()
+			r.Use(m.Middleware(), api.Recovery(api.HandleRecovery()))
+
+			r.GET("/users/:id", func(ctx *gin.Context) {
+				api.Success(ctx, ctx.Param("id"))
+			})
+			r.GET("/error", func(ctx *gin.Context) {
+				api.Error(ctx, errcode.ErrNotFound)
+			})
+			r.GET("/panic", func(_ *gin.Context) {
+				panic(errors.New("boom"))
+			})
+			r.GET("/limited", ratelimit.NewLimiter(1, time.Minute).Middleware(), func(ctx *gin.Context) {
+				api.Success(ctx, "ok")
+			})
+
+			for range tt.requests {
+				r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.uri, nil))
+			}
+
+			route := tt.labels[:2]
+
+			assert.InDelta(t, 1, testutil.ToFloat64(m.requests.WithLabelValues(tt.labels...)), 0)
+			assert.InDelta(t, 0, testutil.ToFloat64(m.inFlight.WithLabelValues(route...)), 0)
+			assert.InDelta(t, tt.panics, testutil.ToFloat64(m.panics.WithLabelValues(route...)), 0)
+			assert.InDelta(t, tt.rejected, testutil.ToFloat64(m.rateLimits.WithLabelValues(route...)), 0)
+			// A series per status
+			assert.Equal(t, tt.requests, testutil.CollectAndCount(m.duration, "test_http_request_duration_seconds"))
+		})
+	}
+}
+
+func TestRouteRegisterMetrics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		token string
+		query string
+		code  int
+	}{
+		{name: "disabled", token: "", query: "?token=", code: http.StatusNotFound},
+		{name: "forbidden", token: "secret", query: "?token=wrong", code: http.StatusForbidden},
+		{name: "ok", token: "secret", query: "?token=secret", code: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := New(WithRegistry(prometheus.NewRegistry()))
+			require.NoError(t, err)
+
+			r := gin.New()
+			r.Use(m.Middleware())
+			RouteRegisterMetrics(r, m, func() string { return tt.token })
+
+			// Records a request before scraping
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics"+tt.query, nil))
+
+			assert.Equal(t, tt.code, w.Code)
+			assert.Equal(t, tt.code == http.StatusOK, strings.Contains(w.Body.String(), `http_requests_total{code=`))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type Option func(*Metrics)
+
+// WithNamespace prefix of the metric names, e.g. "myapp" → myapp_http_requests_total.
+func WithNamespace(ns string) Option {
+	return func(m *Metrics) {
+		m.namespace = ns
+	}
+}
+
+// WithRegistry registers the collectors to the registry and exposes it,
+// default prometheus.DefaultRegisterer and prometheus.DefaultGatherer.
+func WithRegistry(reg *prometheus.Registry) Option {
+	return func(m *Metrics) {
+		if reg != nil {
+			m.registerer = reg
+			m.gatherer = reg
+		}
+	}
+}
+
+// WithDurationBuckets buckets of the request duration in seconds, default prometheus.DefBuckets.
+func WithDurationBuckets(bs ...float64) Option {
+	return func(m *Metrics) {
+		if len(bs) > 0 {
+			m.durationBuckets = bs
+		}
+	}
+}
+
+// WithSizeBuckets buckets of the response size in bytes, default 100B to 10MB.
+func WithSizeBuckets(bs ...float64) Option {
+	return func(m *Metrics) {
+		if len(bs) > 0 {
+			m.sizeBuckets = bs
+		}
+	}
+}
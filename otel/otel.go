@@ -0,0 +1,152 @@
+package otel
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+
+	api "github.com/litsea/gin-api"
+	"github.com/litsea/gin-api/log"
+	"github.com/litsea/gin-api/ratelimit"
+)
+
+const (
+	ScopeName = "github.com/litsea/gin-api/otel"
+
+	// AttrErrorCode errcode code of the error response.
+	AttrErrorCode = attribute.Key("api.error.code")
+
+	// EventRateLimitRejected span event of the requests rejected by the rate limit.
+	EventRateLimitRejected = "ratelimit.rejected"
+
+	metricRequestDuration = "http.server.request.duration"
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Middleware starts a server span per request named by the route (ctx.FullPath()),
+// and records the request duration. Use it before the other middlewares, e.g. Recovery(),
+// the errcode code, panics and rate limit rejections are recorded to the span.
+func Middleware(opts ...Option) gin.HandlerFunc {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	tracer := c.tracerProvider.Tracer(ScopeName, trace.WithSchemaURL(semconv.SchemaURL))
+
+	duration, err := c.meterProvider.Meter(ScopeName, metric.WithSchemaURL(semconv.SchemaURL)).Float64Histogram(
+		metricRequestDuration,
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of HTTP server requests."),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		method := ctx.Request.Method
+		route := ctx.FullPath()
+
+		name := route
+		if name == "" {
+			name = method
+		}
+
+		attrs := []attribute.KeyValue{
+			semconv.HTTPRequestMethodKey.String(method),
+			semconv.URLPath(ctx.Request.URL.Path),
+			semconv.ClientAddress(ctx.ClientIP()),
+			semconv.UserAgentOriginal(ctx.Request.UserAgent()),
+		}
+
+		if route != "" {
+			attrs = append(attrs, semconv.HTTPRoute(route))
+		}
+
+		pctx := c.propagator.Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+		sctx, span := tracer.Start(pctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(sctx)
+
+		// Logged trace and span IDs are of the server span
+		if sc := span.SpanContext(); sc.IsValid() {
+			log.SetTraceContextToContext(ctx, traceContext(sc, trace.SpanContextFromContext(pctx)))
+		}
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+
+		if code, ok := log.GetErrorCodeFromContext(ctx); ok {
+			span.SetAttributes(AttrErrorCode.Int(code))
+		}
+
+		if t, ok := ratelimit.GetRejectedFromContext(ctx); ok {
+			span.AddEvent(EventRateLimitRejected, trace.WithTimestamp(t))
+		}
+
+		switch err := api.GetPanicErrorFromContext(ctx); {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		case status >= http.StatusInternalServerError:
+			// Only server errors are errors of server spans
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		if duration != nil {
+			mattrs := []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String(method),
+				semconv.HTTPResponseStatusCode(status),
+			}
+
+			if route != "" {
+				mattrs = append(mattrs, semconv.HTTPRoute(route))
+			}
+
+			duration.Record(sctx, time.Since(start).Seconds(), metric.WithAttributes(mattrs...))
+		}
+	}
+}
+
+// traceContext the log trace context of the span, the parent is the remote span of the request.
+func traceContext(sc, parent trace.SpanContext) log.TraceContext {
+	tc := log.TraceContext{
+		TraceID: sc.TraceID().String(),
+		SpanID:  sc.SpanID().String(),
+		Flags:   byte(sc.TraceFlags()),
+		State:   sc.TraceState().String(),
+	}
+
+	if parent.IsValid() {
+		tc.ParentSpanID = parent.SpanID().String()
+	}
+
+	return tc
+}
@@ -0,0 +1,203 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+
+	api "github.com/litsea/gin-api"
+	"github.com/litsea/gin-api/errcode"
+	"github.com/litsea/gin-api/log"
+	"github.com/litsea/gin-api/ratelimit"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		uri      string
+		requests int
+		span     string
+		status   int
+		code     int
+		err      bool
+		panic    bool
+		rejected bool
+	}{
+		{name: "success", uri: "/users/1", requests: 1, span: "/users/:id", status: http.StatusOK},
+		{name: "error", uri: "/error", requests: 1, span: "/error", status: http.StatusNotFound, code: errcode.ErrNotFound.Code},
+		{
+			name: "panic", uri: "/panic", requests: 1, span: "/panic", status: http.StatusInternalServerError,
+			code: errcode.ErrInternalServer.Code, err: true, panic: true,
+		},
+		{
+			name: "rate-limit", uri: "/limited", requests: 2, span: "/limited", status: http.StatusTooManyRequests,
+			code: errcode.ErrTooManyRequests.Code, rejected: true,
+		},
+		{name: "not-found", uri: "/missing", requests: 1, span: http.MethodGet, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+			mr := sdkmetric.NewManualReader()
+			mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(mr))
+
+			r := gin.New()
+			r.Use(Middleware(
+				WithTracerProvider(tp),
+				WithMeterProvider(mp),
+				WithPropagator(propagation.TraceContext{}),
+			), api.Recovery(api.HandleRecovery()))
+
+			r.GET("/users/:id", func(ctx *gin.Context) {
+				api.Success(ctx, ctx.Param("id"))
+			})
+			r.GET("/error", func(ctx *gin.Context) {
+				api.Error(ctx, errcode.ErrNotFound)
+			})
+			r.GET("/panic", func(_ *gin.Context) {
+				panic(errors.New("boom"))
+			})
+			r.GET("/limited", ratelimit.NewLimiter(1, time.Minute).Middleware(), func(ctx *gin.Context) {
+				api.Success(ctx, "ok")
+			})
+
+			// The status of the last request
+			var w *httptest.ResponseRecorder
+
+			for range tt.requests {
+				req := httptest.NewRequest(http.MethodGet, tt.uri, nil)
+				req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+				w = httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+			}
+
+			assert.Equal(t, tt.status, w.Code)
+
+			spans := sr.Ended()
+			require.Len(t, spans, tt.requests)
+
+			s := spans[len(spans)-1]
+			assert.Equal(t, tt.span, s.Name())
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", s.SpanContext().TraceID().String())
+			assert.Equal(t, "00f067aa0ba902b7", s.Parent().SpanID().String())
+
+			attrs := attribute.NewSet(s.Attributes()...)
+
+			status, _ := attrs.Value(semconv.HTTPResponseStatusCodeKey)
+			assert.Equal(t, int64(tt.status), status.AsInt64())
+
+			code, ok := attrs.Value(AttrErrorCode)
+			assert.Equal(t, tt.code != 0, ok)
+			assert.Equal(t, int64(tt.code), code.AsInt64())
+
+			assert.Equal(t, tt.err, s.Status().Code == codes.Error)
+
+			var events []string
+			for _, ev := range s.Events() {
+				events = append(events, ev.Name)
+			}
+
+			assert.Equal(t, tt.panic, assert.ObjectsAreEqual([]string{semconv.ExceptionEventName}, events))
+			assert.Equal(t, tt.rejected, assert.ObjectsAreEqual([]string{EventRateLimitRejected}, events))
+
+			var rm metricdata.ResourceMetrics
+			require.NoError(t, mr.Collect(context.Background(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+
+			m := rm.ScopeMetrics[0].Metrics[0]
+			assert.Equal(t, metricRequestDuration, m.Name)
+
+			var count uint64
+			for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+				count += dp.Count
+			}
+
+			assert.Equal(t, uint64(tt.requests), count)
+		})
+	}
+}
+
+func TestLogTraceContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		logFirst bool
+	}{
+		{name: "log-middleware-first", logFirst: true},
+		{name: "otel-middleware-first"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+			buffer := new(strings.Builder)
+			lm := log.Middleware(log.New(slog.New(slog.NewTextHandler(buffer, nil)), log.WithTraceContext(true)))
+			om := Middleware(WithTracerProvider(tp), WithPropagator(propagation.TraceContext{}))
+
+			r := gin.New()
+			if tt.logFirst {
+				r.Use(lm, om)
+			} else {
+				r.Use(om, lm)
+			}
+
+			var (
+				tc     log.TraceContext
+				header = http.Header{}
+			)
+
+			r.GET("/users/:id", func(ctx *gin.Context) {
+				tc, _ = log.GetTraceContext(ctx)
+				log.InjectTraceContext(ctx, header)
+				log.InfoRequest(ctx, "user", nil)
+				api.Success(ctx, ctx.Param("id"))
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+			req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+
+			sc := spans[0].SpanContext()
+			assert.Equal(t, log.TraceContext{
+				TraceID:      sc.TraceID().String(),
+				ParentSpanID: "00f067aa0ba902b7",
+				SpanID:       sc.SpanID().String(),
+				Flags:        0x01,
+			}, tc)
+			assert.Equal(t, "00-"+sc.TraceID().String()+"-"+sc.SpanID().String()+"-01", header.Get(log.TraceParentHeader))
+			assert.Contains(t, buffer.String(), "trace_id="+sc.TraceID().String()+" span_id="+sc.SpanID().String())
+		})
+	}
+}
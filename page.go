@@ -0,0 +1,271 @@
+package api
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+var errBindCursorWithoutCodec = errors.New("bind cursor without codec")
+
+const (
+	defaultPageSize    = 20
+	defaultMaxPageSize = 100
+)
+
+// PageRequest page number pagination params, can be embedded into the request struct.
+type PageRequest struct {
+	Page  int    `form:"page" json:"page"` // Current page number, start from 1
+	Size  int    `form:"size" json:"size"` // Page size
+	Sort  string `form:"sort" json:"sort"` // Sort fields, e.g. "-created_at,name"
+	sorts []SortField
+}
+
+// CursorRequest cursor pagination params, can be embedded into the request struct.
+type CursorRequest struct {
+	Cursor string `form:"cursor" json:"cursor"` // Opaque cursor token, empty for the first page
+	Size   int    `form:"size"   json:"size"`   // Page size
+	Sort   string `form:"sort"   json:"sort"`   // Sort fields, e.g. "-created_at,name"
+	sorts  []SortField
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+type pageConfig struct {
+	defaultSize int
+	maxSize     int
+	defaultSort string
+	sortFields  []string
+}
+
+type PageOption func(*pageConfig)
+
+func WithPageDefaultSize(n int) PageOption {
+	return func(c *pageConfig) {
+		if n > 0 {
+			c.defaultSize = n
+		}
+	}
+}
+
+func WithPageMaxSize(n int) PageOption {
+	return func(c *pageConfig) {
+		if n > 0 {
+			c.maxSize = n
+		}
+	}
+}
+
+// WithPageSortFields sort fields whitelist, the sort param is ignored without whitelist.
+func WithPageSortFields(fields ...string) PageOption {
+	return func(c *pageConfig) {
+		c.sortFields = fields
+	}
+}
+
+// WithPageDefaultSort used when the sort param is empty, e.g. "-id".
+func WithPageDefaultSort(sort string) PageOption {
+	return func(c *pageConfig) {
+		c.defaultSort = sort
+	}
+}
+
+func newPageConfig(opts ...PageOption) *pageConfig {
+	c := &pageConfig{
+		defaultSize: defaultPageSize,
+		maxSize:     defaultMaxPageSize,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.defaultSize > c.maxSize {
+		c.defaultSize = c.maxSize
+	}
+
+	return c
+}
+
+// Normalize apply default values and validate the params,
+// err is validator.ValidationErrors which can be responded by VError.
+func (r *PageRequest) Normalize(opts ...PageOption) error {
+	c := newPageConfig(opts...)
+	ve := validator.ValidationErrors{}
+
+	if r.Page == 0 {
+		r.Page = 1
+	} else if r.Page < 1 {
+		ve = append(ve, newFieldError("page", "min", r.Page, "1"))
+	}
+
+	if fe := c.normalizeSize(&r.Size); fe != nil {
+		ve = append(ve, fe)
+	}
+
+	sorts, fe := c.parseSort(r.Sort)
+	if fe != nil {
+		ve = append(ve, fe)
+	}
+	r.sorts = sorts
+
+	if len(ve) > 0 {
+		return ve
+	}
+
+	return nil
+}
+
+// Sorts parsed and whitelisted sort fields.
+func (r *PageRequest) Sorts() []SortField {
+	return r.sorts
+}
+
+func (r *PageRequest) Offset() int {
+	return (r.Page - 1) * r.Size
+}
+
+func (r *PageRequest) Limit() int {
+	return r.Size
+}
+
+// Normalize apply default values and validate the params except the cursor,
+// err is validator.ValidationErrors which can be responded by VError.
+func (r *CursorRequest) Normalize(opts ...PageOption) error {
+	c := newPageConfig(opts...)
+	ve := validator.ValidationErrors{}
+
+	if fe := c.normalizeSize(&r.Size); fe != nil {
+		ve = append(ve, fe)
+	}
+
+	sorts, fe := c.parseSort(r.Sort)
+	if fe != nil {
+		ve = append(ve, fe)
+	}
+	r.sorts = sorts
+
+	if len(ve) > 0 {
+		return ve
+	}
+
+	return nil
+}
+
+// DecodeCursor decode the cursor token into v, v is untouched for the first page,
+// the error of DecodeCursor() can be responded by Error.
+func (r *CursorRequest) DecodeCursor(codec CursorCodec, v any) error {
+	if r.Cursor == "" {
+		return nil
+	}
+
+	return DecodeCursor(codec, r.Cursor, v)
+}
+
+// Sorts parsed and whitelisted sort fields.
+func (r *CursorRequest) Sorts() []SortField {
+	return r.sorts
+}
+
+func (c *pageConfig) normalizeSize(size *int) *fieldError {
+	switch {
+	case *size == 0:
+		*size = c.defaultSize
+	case *size < 1:
+		return newFieldError("size", "min", *size, "1")
+	case *size > c.maxSize:
+		return newFieldError("size", "max", *size, strconv.Itoa(c.maxSize))
+	}
+
+	return nil
+}
+
+func (c *pageConfig) parseSort(sort string) ([]SortField, *fieldError) {
+	if len(c.sortFields) == 0 {
+		return nil, nil
+	}
+
+	if sort == "" {
+		sort = c.defaultSort
+	}
+
+	if sort == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(sort, ",")
+	sorts := make([]SortField, 0, len(parts))
+
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		desc := strings.HasPrefix(p, "-")
+		f := strings.TrimLeft(p, "+-")
+
+		if !slices.Contains(c.sortFields, f) {
+			return nil, newFieldError("sort", "oneof", p, strings.Join(c.sortFields, " "))
+		}
+
+		sorts = append(sorts, SortField{Field: f, Desc: desc})
+	}
+
+	return sorts, nil
+}
+
+// BindPage bind and validate the page number pagination query params,
+// the VError response has been written when ok is false.
+func BindPage(ctx *gin.Context, opts ...PageOption) (*PageRequest, bool) {
+	req := &PageRequest{}
+
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		VError(ctx, err, req)
+		return nil, false
+	}
+
+	if err := req.Normalize(opts...); err != nil {
+		VError(ctx, err, req)
+		return nil, false
+	}
+
+	return req, true
+}
+
+// BindCursor bind and validate the cursor pagination query params and decode the cursor into cursor,
+// the codec set by CursorCodecMiddleware is used when codec is nil.
+// The error response has been written when ok is false, errcode.ErrBadRequestCursorInvalid
+// or errcode.ErrBadRequestCursorExpired if the cursor can not be decoded.
+func BindCursor(ctx *gin.Context, codec CursorCodec, cursor any, opts ...PageOption) (*CursorRequest, bool) {
+	if codec == nil {
+		codec = GetCursorCodecFromContext(ctx)
+	}
+
+	if codec == nil {
+		Error(ctx, errBindCursorWithoutCodec)
+		return nil, false
+	}
+
+	req := &CursorRequest{}
+
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		VError(ctx, err, req)
+		return nil, false
+	}
+
+	if err := req.Normalize(opts...); err != nil {
+		VError(ctx, err, req)
+		return nil, false
+	}
+
+	if err := req.DecodeCursor(codec, cursor); err != nil {
+		Error(ctx, err)
+		return nil, false
+	}
+
+	return req, true
+}
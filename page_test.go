@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	g18n "github.com/litsea/gin-i18n"
+	"github.com/litsea/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/litsea/gin-api/errcode"
+)
+
+var testCursorCodec = NewHMACCursorCodec([]byte("test-key"))
+
+type testCursor struct {
+	ID int `json:"id"`
+}
+
+func newPageServer() *gin.Engine {
+	gi := g18n.New(
+		g18n.WithOptions(
+			i18n.WithLanguages(language.English),
+			i18n.WithLoaders(i18n.EmbedLoader(Localize, "./localize/")),
+		),
+	)
+
+	r := newServer(gi.Localize())
+
+	opts := []PageOption{
+		WithPageDefaultSize(10),
+		WithPageMaxSize(50),
+		WithPageSortFields("id", "name"),
+		WithPageDefaultSort("-id"),
+	}
+
+	r.GET("/page", func(ctx *gin.Context) {
+		req, ok := BindPage(ctx, opts...)
+		if !ok {
+			return
+		}
+
+		Success(ctx, gin.H{
+			"page": req.Page, "size": req.Size, "offset": req.Offset(), "sorts": req.Sorts(),
+		})
+	})
+
+	r.GET("/cursor", func(ctx *gin.Context) {
+		cur := testCursor{}
+
+		req, ok := BindCursor(ctx, testCursorCodec, &cur, opts...)
+		if !ok {
+			return
+		}
+
+		Success(ctx, gin.H{"id": cur.ID, "size": req.Size})
+	})
+
+	return r
+}
+
+func TestPageRequest(t *testing.T) {
+	t.Parallel()
+
+	token, err := testCursorCodec.Encode(testCursor{ID: 42})
+	assert.NoError(t, err)
+
+	forged, err := NewHMACCursorCodec([]byte("forged")).Encode(testCursor{ID: 1})
+	assert.NoError(t, err)
+
+	type want struct {
+		httpCode int
+		code     int
+		msg      string
+		data     string
+		errField string
+		errMsg   string
+	}
+
+	tests := []struct {
+		name string
+		uri  string
+		want want
+	}{
+		{
+			name: "page-default",
+			uri:  "/page",
+			want: want{
+				httpCode: http.StatusOK,
+				data:     `{"offset":0,"page":1,"size":10,"sorts":[{"Field":"id","Desc":true}]}`,
+			},
+		},
+		{
+			name: "page-valid",
+			uri:  "/page?page=3&size=20&sort=name,-id",
+			want: want{
+				httpCode: http.StatusOK,
+				data: `{"offset":40,"page":3,"size":20,` +
+					`"sorts":[{"Field":"name","Desc":false},{"Field":"id","Desc":true}]}`,
+			},
+		},
+		{
+			name: "page-min",
+			uri:  "/page?page=-1",
+			want: want{httpCode: http.StatusBadRequest, errField: "page", errMsg: "page must be 1 or greater"},
+		},
+		{
+			name: "page-size-max",
+			uri:  "/page?size=51",
+			want: want{httpCode: http.StatusBadRequest, errField: "size", errMsg: "size must be 50 or less"},
+		},
+		{
+			name: "page-sort",
+			uri:  "/page?sort=-password",
+			want: want{httpCode: http.StatusBadRequest, errField: "sort", errMsg: "sort must be one of [id name]"},
+		},
+		{
+			name: "page-numeric",
+			uri:  "/page?page=abc",
+			want: want{
+				httpCode: http.StatusBadRequest,
+				errMsg:   "Invalid numeric format or out of range of request parameter",
+			},
+		},
+		{
+			name: "cursor-first",
+			uri:  "/cursor",
+			want: want{httpCode: http.StatusOK, data: `{"id":0,"size":10}`},
+		},
+		{
+			name: "cursor-valid",
+			uri:  "/cursor?cursor=" + token,
+			want: want{httpCode: http.StatusOK, data: `{"id":42,"size":10}`},
+		},
+		{
+			name: "cursor-forged",
+			uri:  "/cursor?cursor=" + forged,
+			want: want{httpCode: http.StatusBadRequest, code: errcode.ErrBadRequestCursorInvalid.Code, msg: "Invalid pagination cursor"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+
+			w := httptest.NewRecorder()
+			newPageServer().ServeHTTP(w, req)
+
+			var r struct {
+				Response
+				Data json.RawMessage `json:"data"`
+			}
+			err := json.Unmarshal(w.Body.Bytes(), &r)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.httpCode, w.Code)
+
+			if tt.want.code != 0 {
+				assert.Equal(t, tt.want.code, r.Code)
+				assert.Equal(t, tt.want.msg, r.Message)
+			}
+
+			if tt.want.data != "" {
+				assert.JSONEq(t, tt.want.data, string(r.Data))
+			}
+
+			if tt.want.errMsg != "" && assert.Len(t, r.Errors, 1) {
+				assert.Equal(t, tt.want.errField, r.Errors[0].Field)
+				assert.Equal(t, tt.want.errMsg, r.Errors[0].Message)
+			}
+		})
+	}
+}
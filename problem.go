@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/litsea/gin-api/log"
+)
+
+const (
+	ContentTypeProblemJSON = "application/problem+json"
+	ContentTypeProblemXML  = "application/problem+xml"
+
+	defaultProblemType = "about:blank"
+)
+
+// Problem RFC 9457 problem details, code and errors are extension members.
+type Problem struct {
+	XMLName    xml.Name       `json:"-"                  xml:"urn:ietf:rfc:7807 problem"`
+	Type       string         `json:"type"               xml:"type"`
+	Title      string         `json:"title"              xml:"title"`
+	Status     int            `json:"status"             xml:"status"`
+	Detail     string         `json:"detail,omitempty"   xml:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty" xml:"instance,omitempty"`
+	Code       int            `json:"code"               xml:"code"`
+	Errors     []DetailError  `json:"errors,omitempty"   xml:"error,omitempty"`
+	TraceID    string         `json:"trace_id,omitempty" xml:"trace_id,omitempty"`
+	Extensions map[string]any `json:"-"                  xml:"-"` // Additional extension members (JSON only)
+}
+
+type problem Problem
+
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal((*problem)(p))
+	if err != nil {
+		return nil, fmt.Errorf("api.Problem.MarshalJSON: %w", err)
+	}
+
+	if len(p.Extensions) == 0 {
+		return b, nil
+	}
+
+	m := make(map[string]any, len(p.Extensions))
+	maps.Copy(m, p.Extensions)
+
+	// Standard members take precedence over extension members
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("api.Problem.MarshalJSON: %w", err)
+	}
+
+	b, err = json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("api.Problem.MarshalJSON: %w", err)
+	}
+
+	return b, nil
+}
+
+type ProblemOption func(*ProblemFormatter)
+
+// WithProblemTypeBaseURI type member will be "<uri>/<code>", default "about:blank".
+func WithProblemTypeBaseURI(uri string) ProblemOption {
+	return func(f *ProblemFormatter) {
+		f.typeBaseURI = strings.TrimRight(uri, "/")
+	}
+}
+
+// WithProblemExtensions add extension members by the current request context.
+func WithProblemExtensions(fn func(ctx *gin.Context) map[string]any) ProblemOption {
+	return func(f *ProblemFormatter) {
+		f.extensions = fn
+	}
+}
+
+// ProblemFormatter renders errors as RFC 9457 problem details,
+// application/problem+xml for negotiated XML, otherwise application/problem+json.
+type ProblemFormatter struct {
+	typeBaseURI string
+	extensions  func(ctx *gin.Context) map[string]any
+}
+
+func NewProblemFormatter(opts ...ProblemOption) *ProblemFormatter {
+	f := &ProblemFormatter{}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+func (f *ProblemFormatter) Format(ctx *gin.Context, resp *Response) (string, any) {
+	typ := defaultProblemType
+	if f.typeBaseURI != "" {
+		typ = f.typeBaseURI + "/" + strconv.Itoa(resp.Code)
+	}
+
+	p := &Problem{
+		Type:     typ,
+		Title:    http.StatusText(resp.httpCode),
+		Status:   resp.httpCode,
+		Detail:   resp.Message,
+		Instance: log.GetRequestID(ctx),
+		Code:     resp.Code,
+		Errors:   resp.Errors,
+		TraceID:  resp.TraceID,
+	}
+
+	if f.extensions != nil {
+		p.Extensions = f.extensions(ctx)
+	}
+
+	mimeType, _ := NegotiateRenderer(ctx)
+
+	switch {
+	case isXMLMIMEType(mimeType):
+		return ContentTypeProblemXML, p
+	case mimeType == binding.MIMEJSON:
+		return ContentTypeProblemJSON, p
+	default:
+		return "", p
+	}
+}
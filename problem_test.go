@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/litsea/gin-api/errcode"
+	"github.com/litsea/gin-api/log"
+)
+
+func TestProblemResponse(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		httpCode int
+		typ      string
+		title    string
+		detail   string
+		code     int
+		field    string
+	}
+
+	tests := []struct {
+		name string
+		uri  string
+		want want
+	}{
+		{
+			name: "403",
+			uri:  "/403",
+			want: want{
+				httpCode: http.StatusForbidden,
+				typ:      "https://example.com/errors/403",
+				title:    "Forbidden",
+				detail:   "ErrForbidden",
+				code:     errcode.ErrForbidden.Code,
+			},
+		},
+		{
+			name: "custom-503",
+			uri:  "/custom-503",
+			want: want{
+				httpCode: http.StatusServiceUnavailable,
+				typ:      "https://example.com/errors/999",
+				title:    "Service Unavailable",
+				detail:   "errCustom503",
+				code:     errCustom503.Code,
+			},
+		},
+		{
+			name: "validation-required",
+			uri:  "/validation/required",
+			want: want{
+				httpCode: http.StatusBadRequest,
+				typ:      "https://example.com/errors/400",
+				title:    "Bad Request",
+				detail:   "ErrBadRequest",
+				code:     errcode.ErrBadRequest.Code,
+				field:    "name",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+			req.Header.Set("X-Request-ID", "req-"+tt.name)
+
+			l := log.New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			s := newServer(
+				log.Middleware(l),
+				ErrorFormat(NewProblemFormatter(
+					WithProblemTypeBaseURI("https://example.com/errors/"),
+					WithProblemExtensions(func(_ *gin.Context) map[string]any {
+						return map[string]any{"docs": "https://example.com/docs", "status": 0}
+					}),
+				)),
+			)
+
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, req)
+
+			var p map[string]any
+			err := json.Unmarshal(w.Body.Bytes(), &p)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.httpCode, w.Code)
+			assert.Equal(t, ContentTypeProblemJSON, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.want.typ, p["type"])
+			assert.Equal(t, tt.want.title, p["title"])
+			assert.InDelta(t, tt.want.httpCode, p["status"], 0)
+			assert.Equal(t, tt.want.detail, p["detail"])
+			assert.Equal(t, "req-"+tt.name, p["instance"])
+			assert.InDelta(t, tt.want.code, p["code"], 0)
+			assert.Equal(t, "https://example.com/docs", p["docs"])
+
+			if tt.want.field != "" {
+				errs, _ := p["errors"].([]any)
+				if assert.Len(t, errs, 1) {
+					de, _ := errs[0].(map[string]any)
+					assert.Equal(t, tt.want.field, de["field"])
+				}
+			}
+		})
+	}
+}
@@ -1,16 +1,21 @@
 package ratelimit
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	api "github.com/litsea/gin-api"
 	"github.com/litsea/gin-api/errcode"
 )
 
+const rejectedCtxKey = "litsea.gin-api.ratelimit.rejected"
+
 func (l *Limiter) Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		err := l.LimitByRequest(c.Writer, c.Request)
 		if err != nil {
+			c.Set(rejectedCtxKey, time.Now())
 			api.Error(c, errcode.ErrTooManyRequests)
 			c.Abort()
 			return
@@ -19,3 +24,15 @@ func (l *Limiter) Middleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// GetRejectedFromContext the time the request is rejected by the rate limit.
+func GetRejectedFromContext(ctx *gin.Context) (time.Time, bool) {
+	v, ok := ctx.Get(rejectedCtxKey)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t, ok := v.(time.Time)
+
+	return t, ok
+}
@@ -13,6 +13,20 @@ import (
 	"github.com/litsea/gin-api/log"
 )
 
+const panicErrorCtxKey = "litsea.gin-api.panic-error"
+
+// GetPanicErrorFromContext the panic error recovered by Recovery(), nil if no panic.
+func GetPanicErrorFromContext(ctx *gin.Context) error {
+	v, ok := ctx.Get(panicErrorCtxKey)
+	if !ok {
+		return nil
+	}
+
+	err, _ := v.(error)
+
+	return err
+}
+
 func HandleRecovery() gin.RecoveryFunc {
 	return func(ctx *gin.Context, err any) {
 		rErr, ok := err.(error)
@@ -53,6 +67,8 @@ func Recovery(recovery gin.RecoveryFunc) gin.HandlerFunc {
 				}
 
 				if brokenPipe {
+					ctx.Set(panicErrorCtxKey, rErr)
+
 					// If the connection is dead, we can't write a status to it.
 					l.ErrorRequest(ctx, msgErr, map[string]any{
 						"status": http.StatusInternalServerError,
@@ -68,6 +84,7 @@ func Recovery(recovery gin.RecoveryFunc) gin.HandlerFunc {
 					rErr = fmt.Errorf("%v", err)
 				}
 
+				ctx.Set(panicErrorCtxKey, rErr)
 				recovery(ctx, rErr)
 			}
 		}()
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/litsea/gin-api/i18n"
+)
+
+const renderOfferedCtxKey = "litsea.gin-api.render-offered"
+
+var _ Renderer = RendererFunc(nil)
+
+// Renderer writes the response body in a specific format.
+type Renderer interface {
+	Render(ctx *gin.Context, code int, obj any)
+}
+
+type RendererFunc func(ctx *gin.Context, code int, obj any)
+
+func (f RendererFunc) Render(ctx *gin.Context, code int, obj any) {
+	f(ctx, code, obj)
+}
+
+type rendererRegistry struct {
+	mu        sync.RWMutex
+	mimeTypes []string
+	renderers map[string]Renderer
+}
+
+var (
+	jsonRenderer = RendererFunc(func(ctx *gin.Context, code int, obj any) {
+		ctx.JSON(code, obj)
+	})
+
+	renderers = &rendererRegistry{
+		renderers: map[string]Renderer{},
+	}
+)
+
+func init() {
+	xmlRenderer := RendererFunc(func(ctx *gin.Context, code int, obj any) {
+		ctx.XML(code, obj)
+	})
+	yamlRenderer := RendererFunc(func(ctx *gin.Context, code int, obj any) {
+		ctx.YAML(code, obj)
+	})
+
+	RegisterRenderer(binding.MIMEJSON, jsonRenderer)
+	RegisterRenderer(binding.MIMEXML, xmlRenderer)
+	RegisterRenderer(binding.MIMEXML2, xmlRenderer)
+	RegisterRenderer(binding.MIMEYAML, yamlRenderer)
+	RegisterRenderer(binding.MIMEYAML2, yamlRenderer)
+	RegisterRenderer(binding.MIMETOML, RendererFunc(func(ctx *gin.Context, code int, obj any) {
+		ctx.TOML(code, obj)
+	}))
+}
+
+// RegisterRenderer add or replace the renderer of a MIME type,
+// the registration order is the preference order when the client accepts any type.
+func RegisterRenderer(mimeType string, r Renderer) {
+	if mimeType == "" || r == nil {
+		return
+	}
+
+	renderers.mu.Lock()
+	defer renderers.mu.Unlock()
+
+	if _, ok := renderers.renderers[mimeType]; !ok {
+		renderers.mimeTypes = append(renderers.mimeTypes, mimeType)
+	}
+	renderers.renderers[mimeType] = r
+}
+
+// GetRenderer get the registered renderer of a MIME type.
+//
+//nolint:ireturn
+func GetRenderer(mimeType string) (Renderer, bool) {
+	renderers.mu.RLock()
+	defer renderers.mu.RUnlock()
+
+	r, ok := renderers.renderers[mimeType]
+
+	return r, ok
+}
+
+// RegisteredMIMETypes registered MIME types in registration order.
+func RegisteredMIMETypes() []string {
+	renderers.mu.RLock()
+	defer renderers.mu.RUnlock()
+
+	return slices.Clone(renderers.mimeTypes)
+}
+
+// Negotiate middleware enables content negotiation on the Accept header
+// for an engine or a route group, offered MIME types default to all registered.
+// Without this middleware, responses are always rendered as JSON.
+func Negotiate(offered ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ms := offered
+		if len(ms) == 0 {
+			ms = RegisteredMIMETypes()
+		}
+
+		ctx.Set(renderOfferedCtxKey, ms)
+		ctx.Writer.Header().Add("Vary", "Accept")
+
+		ctx.Next()
+	}
+}
+
+// NegotiateRenderer returns the MIME type and renderer for the current request,
+// fallback to JSON when nothing acceptable is offered.
+//
+//nolint:ireturn
+func NegotiateRenderer(ctx *gin.Context) (string, Renderer) {
+	offered := ctx.GetStringSlice(renderOfferedCtxKey)
+	if len(offered) == 0 {
+		return binding.MIMEJSON, jsonRenderer
+	}
+
+	mimeType := ctx.NegotiateFormat(offered...)
+	if r, ok := GetRenderer(mimeType); ok {
+		return mimeType, r
+	}
+
+	return binding.MIMEJSON, jsonRenderer
+}
+
+// renderResponse writes obj with the negotiated renderer,
+// Content-Language is the language of the translations, which varies with Accept-Language.
+func renderResponse(ctx *gin.Context, code int, obj any) {
+	if lng, ok := i18n.CurrentLanguage(ctx); ok {
+		ctx.Header("Content-Language", lng.String())
+		ctx.Writer.Header().Add("Vary", "Accept-Language")
+	}
+
+	_, r := NegotiateRenderer(ctx)
+	r.Render(ctx, code, obj)
+}
+
+func isXMLMIMEType(mimeType string) bool {
+	return mimeType == binding.MIMEXML || mimeType == binding.MIMEXML2
+}
@@ -0,0 +1,18 @@
+//go:build !nomsgpack
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+)
+
+func init() {
+	msgpackRenderer := RendererFunc(func(ctx *gin.Context, code int, obj any) {
+		ctx.Render(code, render.MsgPack{Data: obj})
+	})
+
+	RegisterRenderer(binding.MIMEMSGPACK, msgpackRenderer)
+	RegisterRenderer(binding.MIMEMSGPACK2, msgpackRenderer)
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	// The renderer registry is global, register before the parallel tests
+	RegisterRenderer("application/vnd.test", RendererFunc(func(ctx *gin.Context, code int, obj any) {
+		r, _ := obj.(*Response)
+		ctx.Data(code, "application/vnd.test", []byte(r.Message))
+	}))
+
+	os.Exit(m.Run())
+}
+
+func TestRenderNegotiate(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		mw     []gin.HandlerFunc
+		uri    string
+		accept string
+	}
+	type want struct {
+		code        int
+		contentType string
+		body        string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "default-json",
+			args: args{uri: "/validation/enum?status=1", accept: "application/xml"},
+			want: want{
+				code:        http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body:        `{"code":0,"msg":"Success"}`,
+			},
+		},
+		{
+			name: "negotiate-no-accept",
+			args: args{mw: []gin.HandlerFunc{Negotiate()}, uri: "/validation/enum?status=1"},
+			want: want{
+				code:        http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body:        `{"code":0,"msg":"Success"}`,
+			},
+		},
+		{
+			name: "negotiate-xml",
+			args: args{mw: []gin.HandlerFunc{Negotiate()}, uri: "/validation/enum?status=1", accept: "application/xml"},
+			want: want{
+				code:        http.StatusOK,
+				contentType: "application/xml; charset=utf-8",
+				body:        `<Response><code>0</code><msg>Success</msg></Response>`,
+			},
+		},
+		{
+			name: "negotiate-yaml",
+			args: args{mw: []gin.HandlerFunc{Negotiate()}, uri: "/validation/enum?status=1", accept: "application/yaml"},
+			want: want{
+				code:        http.StatusOK,
+				contentType: "application/yaml; charset=utf-8",
+				body:        "code: 0\nmsg: Success\n",
+			},
+		},
+		{
+			name: "negotiate-custom",
+			args: args{mw: []gin.HandlerFunc{Negotiate()}, uri: "/403", accept: "application/vnd.test"},
+			want: want{
+				code:        http.StatusForbidden,
+				contentType: "application/vnd.test",
+				body:        "ErrForbidden",
+			},
+		},
+		{
+			name: "negotiate-not-offered",
+			args: args{
+				mw:     []gin.HandlerFunc{Negotiate("application/json", "application/yaml")},
+				uri:    "/validation/enum?status=1",
+				accept: "application/xml",
+			},
+			want: want{
+				code:        http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body:        `{"code":0,"msg":"Success"}`,
+			},
+		},
+		{
+			name: "negotiate-problem-xml",
+			args: args{
+				mw:     []gin.HandlerFunc{Negotiate(), ErrorFormat(NewProblemFormatter())},
+				uri:    "/403",
+				accept: "application/xml",
+			},
+			want: want{
+				code:        http.StatusForbidden,
+				contentType: ContentTypeProblemXML,
+				body: `<problem xmlns="urn:ietf:rfc:7807"><type>about:blank</type><title>Forbidden</title>` +
+					`<status>403</status><detail>ErrForbidden</detail><code>403</code></problem>`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.args.uri, http.NoBody)
+			if tt.args.accept != "" {
+				req.Header.Set("Accept", tt.args.accept)
+			}
+
+			// The Vary header set before is kept
+			mw := append([]gin.HandlerFunc{func(ctx *gin.Context) {
+				ctx.Header("Vary", "Origin")
+			}}, tt.args.mw...)
+
+			w := httptest.NewRecorder()
+			s := newServer(mw...)
+			s.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want.code, w.Code)
+			assert.Equal(t, tt.want.contentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.want.body, w.Body.String())
+
+			vary := []string{"Origin"}
+			if len(tt.args.mw) > 0 {
+				vary = append(vary, "Accept")
+			}
+			assert.Equal(t, vary, w.Header().Values("Vary"))
+		})
+	}
+}
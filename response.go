@@ -20,38 +20,52 @@ import (
 
 const (
 	maxValidationErrorValueLength = 50
+
+	// tagLabel display name of the field in validation messages, translated if it is a message ID.
+	tagLabel = "apilabel"
+	// tagSensitive values of the field are not responded, e.g. `sensitive:"true"`.
+	tagSensitive = "sensitive"
 )
 
 var errInvokeErrorFuncWithoutError = errors.New("invoke error function without error")
 
 type Response struct {
-	Code     int           `json:"code"`
-	Message  string        `json:"msg"`
-	Data     any           `json:"data,omitempty"`
-	Errors   []DetailError `json:"errors,omitempty"`
+	Code     int           `json:"code"               xml:"code"`
+	Message  string        `json:"msg"                xml:"msg"`
+	Data     any           `json:"data,omitempty"     xml:"data,omitempty"`
+	Errors   []DetailError `json:"errors,omitempty"   xml:"error,omitempty"`
+	TraceID  string        `json:"trace_id,omitempty" xml:"trace_id,omitempty"` // Error responses with the log trace context
 	httpCode int
 }
 
 type PageResponse struct {
-	Total int64 `json:"total"`           // Total number of records
-	Size  int   `json:"size"`            // Page size
-	Page  int   `json:"page"`            // Current page number
-	Items any   `json:"items,omitempty"` // Data list
+	Total int64      `json:"total"           xml:"total"`           // Total number of records
+	Size  int        `json:"size"            xml:"size"`            // Page size
+	Page  int        `json:"page"            xml:"page"`            // Current page number
+	Items any        `json:"items,omitempty" xml:"items,omitempty"` // Data list
+	Links *PageLinks `json:"links,omitempty" xml:"links,omitempty"` // Pagination links, see PageLinksMiddleware
 }
 
 type CursorPageResp struct {
-	Total int64 `json:"total"`           // Total number of records
-	Size  int   `json:"size"`            // Page size
-	Start any   `json:"start"`           // Current page cursor start
-	Next  any   `json:"next"`            // Next page cursor start
-	Items any   `json:"items,omitempty"` // Data list
+	Total int64      `json:"total"           xml:"total"`           // Total number of records
+	Size  int        `json:"size"            xml:"size"`            // Page size
+	Start any        `json:"start"           xml:"start"`           // Current page cursor start
+	Next  any        `json:"next"            xml:"next"`            // Next page cursor start
+	Items any        `json:"items,omitempty" xml:"items,omitempty"` // Data list
+	Links *PageLinks `json:"links,omitempty" xml:"links,omitempty"` // Pagination links, see PageLinksMiddleware
 }
 
 type DetailError struct {
-	Code    int    `json:"code,omitempty"`
-	Field   string `json:"field,omitempty"`
-	Message string `json:"msg"`
-	Value   any    `json:"value,omitempty"`
+	Code    int    `json:"code,omitempty"  xml:"code,omitempty"`
+	Field   string `json:"field,omitempty" xml:"field,omitempty"`
+	Message string `json:"msg"             xml:"msg"`
+	Value   any    `json:"value,omitempty" xml:"value,omitempty"`
+	Path    string `json:"path,omitempty"  xml:"path,omitempty"` // Index path of the batch item, see errcode.Multi
+}
+
+// HTTPCode HTTP status code of the error response.
+func (r *Response) HTTPCode() int {
+	return r.httpCode
 }
 
 func NewSuccessResponse(data any) *Response {
@@ -82,15 +96,47 @@ func NewCursorPageResponse(total int64, size int, start, next, items any) *Respo
 }
 
 func Success(ctx *gin.Context, data any) {
-	ctx.JSON(http.StatusOK, NewSuccessResponse(data))
+	renderResponse(ctx, http.StatusOK, NewSuccessResponse(data))
 }
 
+// PageSuccess pagination links are emitted when enabled by PageLinksMiddleware.
 func PageSuccess(ctx *gin.Context, total int64, size, page int, items any) {
-	ctx.JSON(http.StatusOK, NewPageResponse(total, size, page, items))
+	renderResponse(ctx, http.StatusOK, NewSuccessResponse(&PageResponse{
+		Total: total,
+		Size:  size,
+		Page:  page,
+		Items: items,
+		Links: pageLinks(ctx, total, size, page),
+	}))
 }
 
+// CursorPageSuccess start and next are responded as is, or encoded into opaque tokens
+// when a cursor codec is set by CursorCodecMiddleware, then zero cursors (e.g. nil, "", 0)
+// are responded as null, the next cursor is null on the last page.
+// Pagination links are emitted when enabled by PageLinksMiddleware.
 func CursorPageSuccess(ctx *gin.Context, total int64, size int, start, next, items any) {
-	ctx.JSON(http.StatusOK, NewCursorPageResponse(total, size, start, next, items))
+	codec := GetCursorCodecFromContext(ctx)
+
+	var err error
+
+	if start, err = responseCursor(codec, start); err != nil {
+		Error(ctx, fmt.Errorf("api.CursorPageSuccess: encode start cursor: %w", err))
+		return
+	}
+
+	if next, err = responseCursor(codec, next); err != nil {
+		Error(ctx, fmt.Errorf("api.CursorPageSuccess: encode next cursor: %w", err))
+		return
+	}
+
+	renderResponse(ctx, http.StatusOK, NewSuccessResponse(&CursorPageResp{
+		Total: total,
+		Size:  size,
+		Start: start,
+		Next:  next,
+		Items: items,
+		Links: cursorPageLinks(ctx, next),
+	}))
 }
 
 func Error(ctx *gin.Context, err error) {
@@ -101,10 +147,19 @@ func Error(ctx *gin.Context, err error) {
 
 	var (
 		message string
+		errs    []DetailError
 		ee      *errcode.Error
 		ve      validator.ValidationErrors
+		msgErr  string
+		rErr    error
 	)
 
+	if items, ok := errcode.Flatten(err); ok && len(items) > 0 {
+		multiError(ctx, err, items)
+
+		return
+	}
+
 	switch {
 	case errors.As(err, &ee):
 		if ee.HTTPCode() > 0 {
@@ -112,25 +167,13 @@ func Error(ctx *gin.Context, err error) {
 		}
 
 		code = ee.Code
-		message = i18n.E(ctx, ee.Error())
-
-		switch ee.HTTPCode() {
-		case http.StatusBadRequest:
-			l.Debug("HTTP bad request", "err", err)
-		case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound,
-			http.StatusMethodNotAllowed, http.StatusTooManyRequests:
-			// ignore log
-		default:
-			msgErr := fmt.Sprintf("API error: code=%d %s", code, ee.Error())
-			if !ee.IsErrorLogDisabled() {
-				l.ErrorRequest(ctx, msgErr, map[string]any{
-					"status": httpCode,
-					"err":    err,
-				})
-			}
+		message = i18n.E(ctx, ee.Message, ee.Params())
+
+		if ee.Field() != "" {
+			errs = []DetailError{{Code: code, Field: ee.Field(), Message: message}}
 		}
 	case errors.As(err, &ve):
-		validateError(ctx, ve)
+		validateError(ctx, ve, nil)
 
 		return
 	default:
@@ -142,10 +185,6 @@ func Error(ctx *gin.Context, err error) {
 		// Do not send unknown error messages to the frontend
 		message = i18n.E(ctx, errcode.ErrInternalServer.Error())
 
-		var (
-			msgErr string
-			rErr   error
-		)
 		if err != nil {
 			msgErr = fmt.Sprintf("HTTP error: code=%d %s", code, http.StatusText(code))
 			rErr = err
@@ -153,18 +192,118 @@ func Error(ctx *gin.Context, err error) {
 			msgErr = "Incorrect error function invoke"
 			rErr = errInvokeErrorFuncWithoutError
 		}
-
-		l.ErrorRequest(ctx, msgErr, map[string]any{
-			"status": httpCode,
-			"err":    rErr,
-		})
 	}
 
-	ctx.JSON(httpCode, Response{
+	writeError(ctx, &Response{
 		Code:     code,
 		Message:  message,
+		Errors:   errs,
 		httpCode: httpCode,
 	})
+
+	// Logged after the response is written, see log.WithResponseBody()
+	if ee != nil {
+		logErrorCode(ctx, httpCode, ee, ee.IsErrorLogDisabled(), err)
+		return
+	}
+
+	l.ErrorRequest(ctx, msgErr, map[string]any{
+		"status": httpCode,
+		"err":    rErr,
+	})
+}
+
+// multiError response each error of a multi error as a detail error,
+// the representative error is the first server error, otherwise the first error,
+// the code and message are of the error code when all errors have the same code,
+// otherwise of the HTTP status of the representative error.
+func multiError(ctx *gin.Context, err error, items []errcode.Item) {
+	var (
+		rep         *errcode.Error
+		httpCode    int
+		sameCode    = true
+		logDisabled = true
+		errs        = make([]DetailError, 0, len(items))
+	)
+
+	for i, it := range items {
+		ee := errcode.ErrInternalServer // Unknown error
+
+		var e *errcode.Error
+		if errors.As(it.Err, &e) {
+			ee = e
+		}
+
+		hc := ee.HTTPCode()
+		if hc <= 0 {
+			hc = http.StatusInternalServerError
+		}
+
+		errs = append(errs, DetailError{
+			Code:    ee.Code,
+			Field:   ee.Field(),
+			Message: i18n.E(ctx, ee.Message, ee.Params()),
+			Path:    it.Path,
+		})
+
+		if i > 0 {
+			sameCode = sameCode && ee.Code == rep.Code
+		}
+
+		if i == 0 || (httpCode < http.StatusInternalServerError && hc >= http.StatusInternalServerError) {
+			rep, httpCode = ee, hc
+		}
+
+		logDisabled = logDisabled && ee.IsErrorLogDisabled()
+	}
+
+	if !sameCode {
+		rep = httpCodeError(httpCode)
+	}
+
+	writeError(ctx, &Response{
+		Code:     rep.Code,
+		Message:  i18n.E(ctx, rep.Message, rep.Params()),
+		Errors:   errs,
+		httpCode: httpCode,
+	})
+
+	// Logged after the response is written, see log.WithResponseBody()
+	logErrorCode(ctx, httpCode, rep, logDisabled, err)
+}
+
+// httpCodeError registered error of the HTTP status, e.g. errcode.ErrBadRequest.
+func httpCodeError(httpCode int) *errcode.Error {
+	if e, ok := errcode.Lookup(httpCode); ok && e.HTTPCode() == httpCode {
+		return e
+	}
+
+	if httpCode < http.StatusInternalServerError {
+		return errcode.ErrBadRequest
+	}
+
+	return errcode.ErrInternalServer
+}
+
+// logErrorCode log the error by the HTTP status, client errors except 400 are not logged.
+func logErrorCode(ctx *gin.Context, httpCode int, ee *errcode.Error, logDisabled bool, err error) {
+	l := log.GetLoggerFromContext(ctx)
+
+	switch httpCode {
+	case http.StatusBadRequest:
+		l.Debug("HTTP bad request", "err", err)
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound,
+		http.StatusMethodNotAllowed, http.StatusTooManyRequests:
+		// ignore log
+	default:
+		msgErr := fmt.Sprintf("API error: code=%d %s", ee.Code, ee.Message)
+		if !logDisabled {
+			l.ErrorRequest(ctx, msgErr, map[string]any{
+				"status": httpCode,
+				"err":    err,
+			})
+		}
+	}
 }
 
 // VError response validate errors.
@@ -182,29 +321,63 @@ func VError(ctx *gin.Context, err error, req any) {
 
 	switch {
 	case errors.As(err, &ve):
-		validateError(ctx, ve)
+		validateError(ctx, ve, req)
 	default:
 		validateParseError(ctx, err)
 	}
 }
 
-// validateError response validate errors.
-func validateError(ctx *gin.Context, ve validator.ValidationErrors) {
+// validateError response validate errors, the field names, labels and sensitive fields
+// are resolved with the req type.
+func validateError(ctx *gin.Context, ve validator.ValidationErrors, req any) {
 	ec := errcode.ErrBadRequest
 	errs := make([]DetailError, len(ve))
 
 	for i, fe := range ve {
-		msg := i18n.V(ctx, fe)
+		field, sf, ok := validationField(ctx, fe, req)
 
-		errs[i] = DetailError{
-			0, fe.Field(), msg,
-			html.EscapeString(ellipsis.Centering(
+		var (
+			opts  []i18n.VOption
+			value any = html.EscapeString(ellipsis.Centering(
 				fmt.Sprintf("%v", fe.Value()), maxValidationErrorValueLength),
-			),
+			)
+		)
+
+		opts = append(opts, i18n.WithNamespace(validationNamespace(ctx, fe, req)))
+
+		if ok {
+			opts = append(opts,
+				i18n.WithFieldName(fieldName(sf, fieldTags(ctx))),
+				i18n.WithFieldLabel(sf.Tag.Get(tagLabel)),
+			)
+
+			if sensitive, _ := strconv.ParseBool(sf.Tag.Get(tagSensitive)); sensitive {
+				value = nil
+			}
+		}
+
+		if pf, ok := paramField(fe, req, fieldTags(ctx)); ok {
+			opts = append(opts, i18n.WithParamField(fieldName(pf, fieldTags(ctx)), pf.Tag.Get(tagLabel)))
+		}
+
+		// Struct level errors without a field
+		if fe.Field() == "" {
+			value = nil
+		}
+
+		// The escaped value of DetailError.Value
+		if v, ok := value.(string); ok {
+			opts = append(opts, i18n.WithActualValue(v))
+		}
+
+		errs[i] = DetailError{
+			Field:   field,
+			Message: i18n.V(ctx, fe, opts...),
+			Value:   value,
 		}
 	}
 
-	ctx.JSON(ec.HTTPCode(), Response{
+	writeError(ctx, &Response{
 		Code:     ec.HTTPCode(),
 		Message:  i18n.E(ctx, ec.Error()),
 		Errors:   errs,
@@ -235,7 +408,7 @@ func validateParseError(ctx *gin.Context, err error) {
 		value = te.Value
 	case errors.As(err, &je):
 		ec = errcode.ErrBadRequestFormatJSON
-		field = je.Field
+		field = jsonFieldPath(ctx, je.Field)
 		value = fmt.Sprintf("expect: %s, actual: %s",
 			je.Type.String(), je.Value)
 	default:
@@ -243,11 +416,11 @@ func validateParseError(ctx *gin.Context, err error) {
 		value = err.Error()
 	}
 
-	ctx.JSON(ec.HTTPCode(), Response{
+	writeError(ctx, &Response{
 		Code:    ec.HTTPCode(),
 		Message: errcode.ErrBadRequest.Error(),
 		Errors: []DetailError{
-			{ec.Code, field, i18n.E(ctx, ec.Error()), value},
+			{Code: ec.Code, Field: field, Message: i18n.E(ctx, ec.Error()), Value: value},
 		},
 		httpCode: ec.HTTPCode(),
 	})
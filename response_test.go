@@ -20,12 +20,14 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/litsea/gin-api/errcode"
+	apii18n "github.com/litsea/gin-api/i18n"
 	"github.com/litsea/gin-api/log"
 	"github.com/litsea/gin-api/testdata"
 )
 
 var (
 	errCustom503   = errcode.New(999, "errCustom503", http.StatusServiceUnavailable)
+	errCustomLimit = errcode.New(998, "errCustomLimit", http.StatusBadRequest)
 	errUnknownCode = errors.New("unknown error code")
 )
 
@@ -85,6 +87,19 @@ func TestResponse(t *testing.T) {
 				msg:      "Internal Server Error",
 			},
 		},
+		{
+			name: "en-custom-limit",
+			args: args{
+				uri: "/custom-limit",
+				lng: language.English,
+			},
+			want: want{
+				httpCode:  errCustomLimit.HTTPCode(),
+				code:      errCustomLimit.Code,
+				msg:       "Must not exceed 10 items",
+				detailMsg: "Must not exceed 10 items",
+			},
+		},
 		{
 			name: "en-validation-required",
 			args: args{
@@ -95,7 +110,7 @@ func TestResponse(t *testing.T) {
 				httpCode:  errcode.ErrBadRequest.HTTPCode(),
 				code:      errcode.ErrBadRequest.Code,
 				msg:       "Bad Request",
-				detailMsg: "Name must not be empty",
+				detailMsg: "name must not be empty",
 			},
 		},
 		{
@@ -108,7 +123,7 @@ func TestResponse(t *testing.T) {
 				httpCode:  errcode.ErrBadRequest.HTTPCode(),
 				code:      errcode.ErrBadRequest.Code,
 				msg:       "Bad Request",
-				detailMsg: "Status must be one of the values [1 2]",
+				detailMsg: "status must be one of the values [1 2]",
 			},
 		},
 		{
@@ -160,6 +175,19 @@ func TestResponse(t *testing.T) {
 				msg:      "Interner Server Fehler",
 			},
 		},
+		{
+			name: "de-custom-limit",
+			args: args{
+				uri: "/custom-limit",
+				lng: language.German,
+			},
+			want: want{
+				httpCode:  errCustomLimit.HTTPCode(),
+				code:      errCustomLimit.Code,
+				msg:       "Darf 10 Elemente nicht überschreiten",
+				detailMsg: "Darf 10 Elemente nicht überschreiten",
+			},
+		},
 		{
 			name: "de-validation-required",
 			args: args{
@@ -170,7 +198,7 @@ func TestResponse(t *testing.T) {
 				httpCode:  errcode.ErrBadRequest.HTTPCode(),
 				code:      errcode.ErrBadRequest.Code,
 				msg:       "Schlechte Anfrage",
-				detailMsg: "Name darf nicht leer sein",
+				detailMsg: "name darf nicht leer sein",
 			},
 		},
 		{
@@ -183,7 +211,7 @@ func TestResponse(t *testing.T) {
 				httpCode:  errcode.ErrBadRequest.HTTPCode(),
 				code:      errcode.ErrBadRequest.Code,
 				msg:       "Schlechte Anfrage",
-				detailMsg: "Status muss einer der Werte [1 2] sein",
+				detailMsg: "status muss einer der Werte [1 2] sein",
 			},
 		},
 		// French (fallback)
@@ -233,7 +261,7 @@ func TestResponse(t *testing.T) {
 				httpCode:  errcode.ErrBadRequest.HTTPCode(),
 				code:      errcode.ErrBadRequest.Code,
 				msg:       "Bad Request",
-				detailMsg: "Name must not be empty",
+				detailMsg: "name must not be empty",
 			},
 		},
 		{
@@ -246,7 +274,7 @@ func TestResponse(t *testing.T) {
 				httpCode:  errcode.ErrBadRequest.HTTPCode(),
 				code:      errcode.ErrBadRequest.Code,
 				msg:       "Bad Request",
-				detailMsg: "Status must be one of the values [1 2]",
+				detailMsg: "status must be one of the values [1 2]",
 			},
 		},
 	}
@@ -275,6 +303,117 @@ func TestResponse(t *testing.T) {
 	}
 }
 
+func TestMultiError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		uri      string
+		httpCode int
+		code     int
+		msg      string
+		errs     []DetailError
+	}{
+		{
+			uri:      "/multi-same",
+			httpCode: http.StatusBadRequest,
+			code:     errCustomLimit.Code,
+			msg:      "Must not exceed 1 items",
+			errs: []DetailError{
+				{Code: errCustomLimit.Code, Field: "items", Message: "Must not exceed 1 items", Path: "[0]"},
+				{Code: errCustomLimit.Code, Message: "Must not exceed 2 items", Path: "[2]"},
+			},
+		},
+		{
+			uri:      "/multi-mixed",
+			httpCode: http.StatusForbidden,
+			code:     errcode.ErrForbidden.Code,
+			msg:      "Forbidden",
+			errs: []DetailError{
+				{Code: errcode.ErrForbidden.Code, Message: "Forbidden", Path: "[1][0]"},
+				{Code: errCustomLimit.Code, Message: "Must not exceed 3 items", Path: "[3]"},
+			},
+		},
+		{
+			uri:      "/multi-server",
+			httpCode: http.StatusInternalServerError,
+			code:     errcode.ErrInternalServer.Code,
+			msg:      "Internal Server Error",
+			errs: []DetailError{
+				{Code: errcode.ErrForbidden.Code, Message: "Forbidden"},
+				{Code: errcode.ErrInternalServer.Code, Message: "Internal Server Error"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			t.Parallel()
+
+			code, data := makeRequest(language.English, tt.uri)
+
+			var r Response
+			assert.NoError(t, json.Unmarshal([]byte(data), &r))
+			assert.Equal(t, tt.httpCode, code)
+			assert.Equal(t, tt.code, r.Code)
+			assert.Equal(t, tt.msg, r.Message)
+			assert.Equal(t, tt.errs, r.Errors)
+		})
+	}
+}
+
+func TestValidationLabel(t *testing.T) {
+	t.Parallel()
+
+	code, data := makeRequest(language.English, "/validation/label?password=secret&nick=long")
+
+	var r Response
+	assert.NoError(t, json.Unmarshal([]byte(data), &r))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, []DetailError{
+		{Field: "password", Message: "Login password must be at least 8 characters in length"},
+		{Field: "nick", Message: "Nickname must be a maximum of 3 characters in length", Value: "long"},
+	}, r.Errors)
+	assert.NotContains(t, data, "secret")
+}
+
+func TestValidationActual(t *testing.T) {
+	t.Parallel()
+
+	code, data := makeRequest(language.English, "/validation/actual?name=%3Cb%3Ex%3C%2Fb%3E&token=s3cret")
+
+	var r Response
+	assert.NoError(t, json.Unmarshal([]byte(data), &r))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, []DetailError{
+		{Field: "name", Message: `name must contain letters only, got "&lt;b&gt;x&lt;/b&gt;"`, Value: "&lt;b&gt;x&lt;/b&gt;"},
+		{Field: "token", Message: `token must contain letters only, got ""`},
+	}, r.Errors)
+	assert.NotContains(t, data, "s3cret")
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	t.Parallel()
+
+	code, data := makeRequest(language.English, "/validation/cross-field?password=a&confirm=b&min=10&max=1")
+
+	var r Response
+	assert.NoError(t, json.Unmarshal([]byte(data), &r))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, []DetailError{
+		{Field: "confirm", Message: "Confirm password must be equal to Login password", Value: "b"},
+		{Field: "max", Message: "max must be greater than min", Value: "1"},
+	}, r.Errors)
+
+	code, data = makeRequest(language.English, "/validation/cross-field?min=1&max=200")
+
+	r = Response{}
+	assert.NoError(t, json.Unmarshal([]byte(data), &r))
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, []DetailError{
+		{Message: "The range must not exceed 100"},
+	}, r.Errors)
+}
+
 type enum interface {
 	IsValidEnum() bool
 }
@@ -306,6 +445,32 @@ func newServer(mw ...gin.HandlerFunc) *gin.Engine {
 		Error(ctx, fmt.Errorf("test: %w", errCustom503))
 	})
 
+	r.GET("/custom-limit", func(ctx *gin.Context) {
+		Error(ctx, fmt.Errorf("test: %w", errCustomLimit.
+			With(map[string]any{"limit": 10}).
+			WithField("items").
+			Wrap(errUnknownCode),
+		))
+	})
+
+	r.GET("/multi-same", func(ctx *gin.Context) {
+		Error(ctx, errors.Join(
+			errcode.At(0, errCustomLimit.With(map[string]any{"limit": 1}).WithField("items")),
+			errcode.At(2, errCustomLimit.With(map[string]any{"limit": 2})),
+		))
+	})
+
+	r.GET("/multi-mixed", func(ctx *gin.Context) {
+		m := errcode.NewMulti()
+		m.AddAt(1, errcode.NewMulti().AddAt(0, errcode.ErrForbidden).Err())
+		m.AddAt(3, fmt.Errorf("test: %w", errCustomLimit.With(map[string]any{"limit": 3})))
+		Error(ctx, m.Err())
+	})
+
+	r.GET("/multi-server", func(ctx *gin.Context) {
+		Error(ctx, errors.Join(errcode.ErrForbidden, errUnknownCode))
+	})
+
 	r.GET("/unknown-500", func(ctx *gin.Context) {
 		Error(ctx, fmt.Errorf("test: %w", errUnknownCode))
 	})
@@ -327,6 +492,45 @@ func newServer(mw ...gin.HandlerFunc) *gin.Engine {
 		Success(ctx, nil)
 	})
 
+	r.GET("/validation/label", func(ctx *gin.Context) {
+		req := &struct {
+			Password string `apilabel:"labelPassword" binding:"min=8" form:"password" sensitive:"true"`
+			Nickname string `apilabel:"Nickname"      binding:"max=3" form:"nick"`
+		}{}
+		if err := ctx.ShouldBind(&req); err != nil {
+			VError(ctx, err, req)
+
+			return
+		}
+
+		Success(ctx, nil)
+	})
+
+	r.GET("/validation/actual", func(ctx *gin.Context) {
+		req := &struct {
+			Name  string `binding:"alpha" form:"name"`
+			Token string `binding:"alpha" form:"token" sensitive:"true"`
+		}{}
+		if err := ctx.ShouldBind(&req); err != nil {
+			VError(ctx, err, req)
+
+			return
+		}
+
+		Success(ctx, nil)
+	})
+
+	r.GET("/validation/cross-field", func(ctx *gin.Context) {
+		req := &testCrossFieldReq{}
+		if err := ctx.ShouldBind(req); err != nil {
+			VError(ctx, err, req)
+
+			return
+		}
+
+		Success(ctx, nil)
+	})
+
 	r.GET("/validation/enum", func(ctx *gin.Context) {
 		req := &struct {
 			Status status `binding:"enum=[1 2]" form:"status"`
@@ -364,6 +568,13 @@ func makeRequest(lng language.Tag, uri string) (int, string) {
 	return w.Code, w.Body.String()
 }
 
+type testCrossFieldReq struct {
+	Password string `apilabel:"labelPassword"    form:"password"`
+	Confirm  string `apilabel:"Confirm password" binding:"eqfield=Password" form:"confirm"`
+	Min      int    `form:"min"`
+	Max      int    `binding:"gtfield=Min"       form:"max"`
+}
+
 func bindValidator() {
 	v, ok := binding.Validator.Engine().(*validator.Validate)
 	if !ok {
@@ -371,6 +582,13 @@ func bindValidator() {
 	}
 
 	_ = v.RegisterValidation("enum", validateEnum)
+
+	v.RegisterStructValidation(func(sl validator.StructLevel) {
+		req, _ := sl.Current().Interface().(testCrossFieldReq)
+		if req.Max-req.Min > 100 {
+			sl.ReportError(nil, "", "", "test_range", "100")
+		}
+	}, testCrossFieldReq{})
 }
 
 func validateEnum(fl validator.FieldLevel) bool {
@@ -381,6 +599,62 @@ func validateEnum(fl validator.FieldLevel) bool {
 	return false
 }
 
+func TestContentLanguage(t *testing.T) {
+	t.Parallel()
+
+	gi := g18n.New(
+		g18n.WithGetLngHandler(apii18n.NegotiateLanguage()),
+		g18n.WithOptions(
+			i18n.WithLanguages(language.English, language.German),
+			i18n.WithLoaders(
+				i18n.EmbedLoader(Localize, "./localize/"),
+				i18n.EmbedLoader(testdata.Localize, "./localize/"),
+			),
+		),
+	)
+
+	tests := []struct {
+		name   string
+		mw     []gin.HandlerFunc
+		uri    string
+		accept string
+		want   string
+		vary   []string
+	}{
+		{
+			name:   "error",
+			mw:     []gin.HandlerFunc{gi.Localize(), Negotiate()},
+			uri:    "/403",
+			accept: "de-CH, en;q=0.5",
+			want:   "de",
+			vary:   []string{"Accept", "Accept-Language"},
+		},
+		{
+			name: "fallback",
+			mw:   []gin.HandlerFunc{gi.Localize()},
+			uri:  "/403?lang=fr",
+			want: "en",
+			vary: []string{"Accept-Language"},
+		},
+		{name: "without-i18n", uri: "/403", accept: "de", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+			req.Header.Set("Accept-Language", tt.accept)
+
+			w := httptest.NewRecorder()
+			newServer(tt.mw...).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Header().Get("Content-Language"))
+			assert.Equal(t, tt.vary, w.Header().Values("Vary"))
+		})
+	}
+}
+
 func TestLoggerInResponse(t *testing.T) {
 	t.Parallel()
 
@@ -429,6 +703,18 @@ func TestLoggerInResponse(t *testing.T) {
 				errUnknownCode.Error(),
 			},
 		},
+		{
+			name: "custom-limit-debug",
+			args: args{
+				uri: "/custom-limit",
+				lv:  slog.LevelDebug,
+			},
+			want: []string{
+				"level=DEBUG",
+				"HTTP bad request",
+				"errCustomLimit: " + errUnknownCode.Error(),
+			},
+		},
 		{
 			name: "invalid-err-func-invoke",
 			args: args{
@@ -0,0 +1,267 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/litsea/gin-api/errcode"
+	"github.com/litsea/gin-api/log"
+)
+
+var (
+	_ log.ResponseBodyBuffer = (*timeoutWriter)(nil)
+
+	errTimeoutHijack = errors.New("hijack is not supported by the timeout middleware")
+)
+
+type timeoutConfig struct {
+	timeout time.Duration
+	routes  map[string]time.Duration
+}
+
+type TimeoutOption func(*timeoutConfig)
+
+// WithRouteTimeout timeout of the route full path, e.g. "/users/:id", 0 disables the timeout of the route.
+func WithRouteTimeout(path string, d time.Duration) TimeoutOption {
+	return func(c *timeoutConfig) {
+		c.routes[path] = d
+	}
+}
+
+// TimeoutMiddleware sets the deadline of the request context and responds with
+// errcode.ErrServiceTimeout when it is exceeded. The response of the handlers is buffered,
+// writes after the deadline are discarded. Handlers should return on ctx.Request.Context().Done(),
+// the gin context is released after the handlers return.
+//
+// Flush() is buffered and Hijack() is not supported, disable the timeout of SSE and WebSocket
+// routes by WithRouteTimeout(path, 0).
+func TimeoutMiddleware(d time.Duration, opts ...TimeoutOption) gin.HandlerFunc {
+	c := &timeoutConfig{
+		timeout: d,
+		routes:  map[string]time.Duration{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return func(ctx *gin.Context) {
+		d, ok := c.routes[ctx.FullPath()]
+		if !ok {
+			d = c.timeout
+		}
+
+		if d <= 0 {
+			ctx.Next()
+			return
+		}
+
+		start := time.Now()
+
+		tctx, cancel := context.WithTimeout(ctx.Request.Context(), d)
+		defer cancel()
+
+		ctx.Request = ctx.Request.WithContext(tctx)
+
+		w := ctx.Writer
+		tw := newTimeoutWriter(w)
+		ctx.Writer = tw
+
+		// Responds the timeout error without sharing the context with the handlers
+		cp := ctx.Copy()
+		cp.Writer = w
+
+		done := make(chan struct{})
+
+		var (
+			panicErr   any
+			panicStack []byte
+		)
+
+		go func() {
+			defer close(done)
+			defer func() {
+				if panicErr = recover(); panicErr != nil {
+					// The stack of the handlers is lost when it is re-panicked
+					panicStack = debug.Stack()
+				}
+			}()
+
+			ctx.Next()
+		}()
+
+		select {
+		case <-done:
+			ctx.Writer = w
+
+			if panicErr != nil {
+				log.SetStackTraceToContext(ctx, panicStack)
+				panic(panicErr) // Handled by the recovery middleware
+			}
+
+			tw.flush()
+		case <-tctx.Done():
+			tw.timeout()
+
+			if errors.Is(tctx.Err(), context.DeadlineExceeded) {
+				log.GetLoggerFromContext(cp).WarnRequest(cp, "Request timeout", map[string]any{
+					"timeout": d.String(),
+					"elapsed": time.Since(start).String(),
+				})
+
+				// Responds with the Content-Length, the client does not wait for the handlers
+				ew := newTimeoutWriter(w)
+				cp.Writer = ew
+
+				Error(cp, errcode.ErrServiceTimeout)
+
+				cp.Writer = w
+				ew.flush()
+				w.Flush()
+			}
+
+			// The gin context is released after the handlers return
+			<-done
+
+			ctx.Writer = w
+			ctx.Abort()
+
+			if code, ok := log.GetErrorCodeFromContext(cp); ok {
+				log.SetErrorCodeToContext(ctx, code)
+			}
+
+			if panicErr != nil {
+				log.SetStackTraceToContext(ctx, panicStack)
+				log.SetStackTraceToContext(cp, panicStack)
+				log.GetLoggerFromContext(cp).ErrorRequest(cp, "Panic error after request timeout", map[string]any{
+					"err": fmt.Errorf("%v", panicErr),
+				})
+			}
+		}
+	}
+}
+
+// timeoutWriter buffers the response until the handlers return.
+type timeoutWriter struct {
+	gin.ResponseWriter
+
+	mu       sync.Mutex
+	header   http.Header
+	body     bytes.Buffer
+	status   int
+	timedOut bool
+}
+
+func newTimeoutWriter(w gin.ResponseWriter) *timeoutWriter {
+	return &timeoutWriter{
+		ResponseWriter: w,
+		header:         w.Header().Clone(),
+		status:         http.StatusOK,
+	}
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if !tw.timedOut && tw.body.Len() == 0 {
+		tw.status = code
+	}
+}
+
+func (tw *timeoutWriter) WriteHeaderNow() {}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	return tw.body.Write(b)
+}
+
+func (tw *timeoutWriter) WriteString(s string) (int, error) {
+	return tw.Write([]byte(s))
+}
+
+func (tw *timeoutWriter) Status() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	return tw.status
+}
+
+func (tw *timeoutWriter) Size() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.body.Len() == 0 {
+		return -1
+	}
+
+	return tw.body.Len()
+}
+
+func (tw *timeoutWriter) Written() bool {
+	return tw.Size() != -1
+}
+
+// Flush the response is written when the handlers return.
+func (tw *timeoutWriter) Flush() {}
+
+func (tw *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, errTimeoutHijack
+}
+
+// BufferedBody the error responses are logged before the handlers return.
+func (tw *timeoutWriter) BufferedBody() []byte {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	return tw.body.Bytes()
+}
+
+func (tw *timeoutWriter) timeout() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	tw.timedOut = true
+}
+
+// flush writes the buffered response to the underlying writer.
+func (tw *timeoutWriter) flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	h := tw.ResponseWriter.Header()
+	clear(h)
+	maps.Copy(h, tw.header)
+
+	if tw.body.Len() > 0 {
+		h.Set("Content-Length", strconv.Itoa(tw.body.Len()))
+	}
+
+	tw.ResponseWriter.WriteHeader(tw.status)
+
+	if tw.body.Len() > 0 {
+		_, _ = tw.ResponseWriter.Write(tw.body.Bytes())
+	}
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/litsea/gin-api/log"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	t.Parallel()
+
+	slow := func(ctx *gin.Context) {
+		select {
+		case <-ctx.Request.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		// Late writes are discarded
+		ctx.Header("X-Late", "1")
+		Success(ctx, "late")
+	}
+
+	tests := []struct {
+		name    string
+		uri     string
+		code    int
+		body    string
+		late    bool
+		wantLog []string
+	}{
+		{name: "fast", uri: "/fast", code: http.StatusOK, body: `{"code":0,"msg":"Success","data":"fast"}`},
+		{
+			name:    "slow",
+			uri:     "/slow",
+			code:    http.StatusServiceUnavailable,
+			body:    `{"code":1101,"msg":"ErrServiceTimeout"}`,
+			wantLog: []string{"Request timeout", "timeout=20ms", "elapsed="},
+		},
+		{name: "route-override", uri: "/slow/allowed", code: http.StatusOK, body: `{"code":0,"msg":"Success","data":"late"}`, late: true},
+		{name: "route-disabled", uri: "/slow/unlimited", code: http.StatusOK, body: `{"code":0,"msg":"Success","data":"late"}`, late: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := new(strings.Builder)
+			l := log.New(slog.New(slog.NewTextHandler(buffer, nil)))
+
+			r := gin.New()
+			r.Use(log.Middleware(l), TimeoutMiddleware(20*time.Millisecond,
+				WithRouteTimeout("/slow/allowed", time.Second),
+				WithRouteTimeout("/slow/unlimited", 0),
+			))
+			r.GET("/fast", func(ctx *gin.Context) {
+				Success(ctx, "fast")
+			})
+			r.GET("/slow", slow)
+			r.GET("/slow/allowed", slow)
+			r.GET("/slow/unlimited", slow)
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+			assert.JSONEq(t, tt.body, w.Body.String())
+			assert.Equal(t, tt.late, w.Header().Get("X-Late") == "1")
+			for _, want := range tt.wantLog {
+				assert.Contains(t, buffer.String(), want)
+			}
+		})
+	}
+}
+
+func TestTimeoutMiddlewareLatency(t *testing.T) {
+	t.Parallel()
+
+	r := gin.New()
+	r.Use(TimeoutMiddleware(50 * time.Millisecond))
+	r.GET("/sleep", func(ctx *gin.Context) {
+		// Ignores the request context
+		time.Sleep(time.Second)
+		Success(ctx, "late")
+	})
+
+	s := httptest.NewServer(r)
+	defer s.Close()
+
+	start := time.Now()
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, s.URL+"/sleep", http.NoBody)
+	resp, err := s.Client().Do(req)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	elapsed := time.Since(start)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.JSONEq(t, `{"code":1101,"msg":"ErrServiceTimeout"}`, string(body))
+	assert.Less(t, elapsed, 500*time.Millisecond)
+}
+
+func TestTimeoutResponseBodyLog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		uri  string
+		want map[string]string // message: response body
+	}{
+		{
+			name: "error",
+			uri:  "/error",
+			want: map[string]string{
+				"API error: code=999 errCustom503": `{"code":999,"msg":"[REDACTED]"}`,
+				"Access":                           `{"code":999,"msg":"[REDACTED]"}`,
+			},
+		},
+		{
+			name: "panic",
+			uri:  "/panic",
+			want: map[string]string{
+				"HTTP error: code=500 Internal Server Error": `{"code":500,"msg":"[REDACTED]"}`,
+				"Access": `{"code":500,"msg":"[REDACTED]"}`,
+			},
+		},
+		{
+			name: "timeout",
+			uri:  "/slow",
+			want: map[string]string{"Access": `{"code":1101,"msg":"[REDACTED]"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := new(strings.Builder)
+			l := log.New(
+				slog.New(slog.NewJSONHandler(buffer, nil)),
+				log.WithAccessLog(true),
+				log.WithResponseBody(true),
+				log.WithRedactFields("$.msg"),
+			)
+
+			r := gin.New()
+			r.Use(log.Middleware(l), Recovery(HandleRecovery()), TimeoutMiddleware(20*time.Millisecond))
+			r.GET("/error", func(ctx *gin.Context) {
+				Error(ctx, errCustom503)
+			})
+			r.GET("/panic", func(_ *gin.Context) {
+				panic("boom")
+			})
+			r.GET("/slow", func(ctx *gin.Context) {
+				<-ctx.Request.Context().Done()
+			})
+
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.uri, http.NoBody)
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			got := map[string]string{}
+
+			for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+				var record struct {
+					Msg      string `json:"msg"`
+					Response struct {
+						Body string `json:"body"`
+					} `json:"response"`
+				}
+
+				if assert.NoError(t, json.Unmarshal([]byte(line), &record)) {
+					got[record.Msg] = record.Response.Body
+				}
+			}
+
+			for msg, body := range tt.want {
+				assert.Equal(t, body, got[msg], msg)
+			}
+		})
+	}
+}
+
+func panicHandler(_ *gin.Context) {
+	panic("boom")
+}
+
+func TestTimeoutPanicStackTrace(t *testing.T) {
+	t.Parallel()
+
+	buffer := new(strings.Builder)
+	l := log.New(
+		slog.New(slog.NewJSONHandler(buffer, nil)),
+		log.WithAccessLog(true),
+		log.WithStackTrace(true),
+	)
+
+	r := gin.New()
+	r.Use(log.Middleware(l), Recovery(HandleRecovery()), TimeoutMiddleware(time.Second))
+	r.GET("/panic", panicHandler)
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/panic", http.NoBody)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	got := map[string]string{}
+
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		var record struct {
+			Msg        string `json:"msg"`
+			StackTrace string `json:"stacktrace"`
+		}
+
+		if assert.NoError(t, json.Unmarshal([]byte(line), &record)) {
+			got[record.Msg] = record.StackTrace
+		}
+	}
+
+	// The stack of the handler goroutine instead of the re-panic in the timeout middleware
+	for _, msg := range []string{"HTTP error: code=500 Internal Server Error", "Access"} {
+		assert.Contains(t, got[msg], "api.panicHandler", msg)
+	}
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TypedResponse generic variant of Response.
+type TypedResponse[T any] struct {
+	Code    int           `json:"code"             xml:"code"`
+	Message string        `json:"msg"              xml:"msg"`
+	Data    T             `json:"data,omitempty"   xml:"data,omitempty"`
+	Errors  []DetailError `json:"errors,omitempty" xml:"error,omitempty"`
+}
+
+// TypedPageResponse generic variant of PageResponse.
+type TypedPageResponse[T any] struct {
+	Total int64      `json:"total"           xml:"total"`           // Total number of records
+	Size  int        `json:"size"            xml:"size"`            // Page size
+	Page  int        `json:"page"            xml:"page"`            // Current page number
+	Items []T        `json:"items,omitempty" xml:"items,omitempty"` // Data list
+	Links *PageLinks `json:"links,omitempty" xml:"links,omitempty"` // Pagination links, see PageLinksMiddleware
+}
+
+// TypedCursorPageResp generic variant of CursorPageResp, C is the cursor type.
+type TypedCursorPageResp[T, C any] struct {
+	Total int64      `json:"total"           xml:"total"`           // Total number of records
+	Size  int        `json:"size"            xml:"size"`            // Page size
+	Start C          `json:"start"           xml:"start"`           // Current page cursor start
+	Next  C          `json:"next"            xml:"next"`            // Next page cursor start
+	Items []T        `json:"items,omitempty" xml:"items,omitempty"` // Data list
+	Links *PageLinks `json:"links,omitempty" xml:"links,omitempty"` // Pagination links, see PageLinksMiddleware
+}
+
+func NewTypedSuccessResponse[T any](data T) *TypedResponse[T] {
+	return &TypedResponse[T]{
+		Code:    0,
+		Message: "Success",
+		Data:    data,
+	}
+}
+
+func NewTypedPageResponse[T any](total int64, size, page int, items []T) *TypedResponse[*TypedPageResponse[T]] {
+	return NewTypedSuccessResponse(&TypedPageResponse[T]{
+		Total: total,
+		Size:  size,
+		Page:  page,
+		Items: items,
+	})
+}
+
+func NewTypedCursorPageResponse[T, C any](
+	total int64, size int, start, next C, items []T,
+) *TypedResponse[*TypedCursorPageResp[T, C]] {
+	return NewTypedSuccessResponse(&TypedCursorPageResp[T, C]{
+		Total: total,
+		Size:  size,
+		Start: start,
+		Next:  next,
+		Items: items,
+	})
+}
+
+func TypedSuccess[T any](ctx *gin.Context, data T) {
+	renderResponse(ctx, http.StatusOK, NewTypedSuccessResponse(data))
+}
+
+// TypedPageSuccess pagination links are emitted when enabled by PageLinksMiddleware.
+func TypedPageSuccess[T any](ctx *gin.Context, total int64, size, page int, items []T) {
+	resp := NewTypedPageResponse(total, size, page, items)
+	resp.Data.Links = pageLinks(ctx, total, size, page)
+
+	renderResponse(ctx, http.StatusOK, resp)
+}
+
+// TypedCursorPageSuccess the next cursor is the zero value of C on the last page.
+// When a cursor codec is set by CursorCodecMiddleware, the cursors are responded
+// as string tokens, empty for the zero cursors.
+// Pagination links are emitted when enabled by PageLinksMiddleware.
+func TypedCursorPageSuccess[T, C any](ctx *gin.Context, total int64, size int, start, next C, items []T) {
+	codec := GetCursorCodecFromContext(ctx)
+	if codec == nil {
+		resp := NewTypedCursorPageResponse(total, size, start, next, items)
+		resp.Data.Links = cursorPageLinks(ctx, next)
+
+		renderResponse(ctx, http.StatusOK, resp)
+
+		return
+	}
+
+	startToken, err := encodeCursor(codec, start)
+	if err != nil {
+		Error(ctx, fmt.Errorf("api.TypedCursorPageSuccess: encode start cursor: %w", err))
+		return
+	}
+
+	nextToken, err := encodeCursor(codec, next)
+	if err != nil {
+		Error(ctx, fmt.Errorf("api.TypedCursorPageSuccess: encode next cursor: %w", err))
+		return
+	}
+
+	resp := NewTypedCursorPageResponse(total, size, startToken, nextToken, items)
+	resp.Data.Links = cursorPageLinks(ctx, nextToken)
+
+	renderResponse(ctx, http.StatusOK, resp)
+}